@@ -0,0 +1,304 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"sports-app/backend/config"
+	"sports-app/backend/models"
+
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+)
+
+// maxNotesLength 与运动记录备注的长度限制保持一致
+const maxNotesLength = 2000
+
+// report 迁移统计
+type report struct {
+	source    string
+	total     int
+	migrated  int
+	skipped   int // 已迁移过的记录
+	failed    int
+	anomalies []string
+}
+
+func (r *report) anomaly(id int64, format string, args ...interface{}) {
+	r.anomalies = append(r.anomalies, fmt.Sprintf("%s#%d: %s", r.source, id, fmt.Sprintf(format, args...)))
+}
+
+func (r *report) print() {
+	fmt.Printf("\n[%s] 共 %d 条，迁移 %d 条，已存在跳过 %d 条，失败 %d 条，异常 %d 条\n",
+		r.source, r.total, r.migrated, r.skipped, r.failed, len(r.anomalies))
+	for _, a := range r.anomalies {
+		fmt.Println("  -", a)
+	}
+}
+
+// migrator 将旧的 exercises/check_ins 表转换为 sport_records
+type migrator struct {
+	db         *gorm.DB
+	dryRun     bool
+	batchSize  int
+	users      map[int64]bool
+	sportTypes map[int64]bool
+	migrated   map[string]map[int64]bool
+}
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "只统计数量和异常，不写入数据库")
+	batchSize := flag.Int("batch", 500, "每批处理的记录数")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("未找到 .env 文件，或加载失败，将尝试使用系统环境变量")
+	}
+	config.GetConfig()
+	db := config.GetDB()
+
+	m := &migrator{db: db, dryRun: *dryRun, batchSize: *batchSize}
+	if err := m.load(); err != nil {
+		log.Fatalf("加载基础数据失败: %v", err)
+	}
+
+	if *dryRun {
+		fmt.Println("dry-run 模式：不会写入任何数据")
+	}
+
+	exercises, err := m.migrateExercises()
+	if err != nil {
+		log.Fatalf("迁移 exercises 失败: %v", err)
+	}
+	checkIns, err := m.migrateCheckIns()
+	if err != nil {
+		log.Fatalf("迁移 check_ins 失败: %v", err)
+	}
+
+	exercises.print()
+	checkIns.print()
+}
+
+// load 预先加载用户、运动类型及已有的映射关系，用于异常检测和幂等
+func (m *migrator) load() error {
+	var userIDs, sportTypeIDs []int64
+	if err := m.db.Model(&models.User{}).Pluck("id", &userIDs).Error; err != nil {
+		return err
+	}
+	if err := m.db.Model(&models.SportType{}).Pluck("id", &sportTypeIDs).Error; err != nil {
+		return err
+	}
+
+	m.users = make(map[int64]bool, len(userIDs))
+	for _, id := range userIDs {
+		m.users[id] = true
+	}
+	m.sportTypes = make(map[int64]bool, len(sportTypeIDs))
+	for _, id := range sportTypeIDs {
+		m.sportTypes[id] = true
+	}
+
+	m.migrated = map[string]map[int64]bool{
+		models.LegacySourceExercise: {},
+		models.LegacySourceCheckIn:  {},
+	}
+	if !m.db.Migrator().HasTable(&models.LegacyRecordMapping{}) {
+		if m.dryRun {
+			return nil
+		}
+		if err := m.db.AutoMigrate(&models.LegacyRecordMapping{}); err != nil {
+			return err
+		}
+	}
+
+	var mappings []models.LegacyRecordMapping
+	if err := m.db.Find(&mappings).Error; err != nil {
+		return err
+	}
+	for _, mapping := range mappings {
+		if ids, ok := m.migrated[mapping.SourceTable]; ok {
+			ids[mapping.SourceID] = true
+		}
+	}
+	return nil
+}
+
+// checkRefs 检查用户和运动类型是否存在
+func (m *migrator) checkRefs(r *report, id, userID, sportTypeID int64) bool {
+	ok := true
+	if !m.users[userID] {
+		r.anomaly(id, "用户 %d 不存在", userID)
+		ok = false
+	}
+	if !m.sportTypes[sportTypeID] {
+		r.anomaly(id, "运动类型 %d 不存在", sportTypeID)
+		ok = false
+	}
+	return ok
+}
+
+// save 写入运动记录及映射关系
+func (m *migrator) save(r *report, sourceID int64, record *models.SportRecord) {
+	if m.dryRun {
+		r.migrated++
+		return
+	}
+
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.LegacyRecordMapping{
+			SourceTable: r.source,
+			SourceID:    sourceID,
+			RecordID:    record.ID,
+		}).Error
+	})
+	if err != nil {
+		r.failed++
+		r.anomaly(sourceID, "写入失败: %v", err)
+		return
+	}
+	r.migrated++
+}
+
+func (m *migrator) migrateExercises() (*report, error) {
+	r := &report{source: models.LegacySourceExercise}
+	if !m.db.Migrator().HasTable(&models.Exercise{}) {
+		return r, nil
+	}
+
+	var batch []models.Exercise
+	err := m.db.Model(&models.Exercise{}).FindInBatches(&batch, m.batchSize, func(tx *gorm.DB, _ int) error {
+		for _, e := range batch {
+			r.total++
+			if m.migrated[r.source][e.ID] {
+				r.skipped++
+				continue
+			}
+			if !m.checkRefs(r, e.ID, e.UserID, e.SportTypeID) {
+				r.failed++
+				continue
+			}
+
+			record := &models.SportRecord{
+				UserID:      e.UserID,
+				SportTypeID: e.SportTypeID,
+				Duration:    int64(e.Duration),
+				Calories:    int64(e.Calories),
+				Distance:    e.Distance,
+				StartTime:   e.Date,
+				ImageURL:    e.ImageURL,
+				ImgURLList:  imageList(e.ImageURL),
+				Visibility:  models.VisibilityPrivate,
+				CreatedAt:   e.CreatedAt,
+				UpdatedAt:   e.UpdatedAt,
+			}
+			m.normalize(r, e.ID, record)
+			m.save(r, e.ID, record)
+		}
+		return nil
+	}).Error
+	return r, err
+}
+
+func (m *migrator) migrateCheckIns() (*report, error) {
+	r := &report{source: models.LegacySourceCheckIn}
+	if !m.db.Migrator().HasTable(&models.CheckIn{}) {
+		return r, nil
+	}
+
+	var batch []models.CheckIn
+	err := m.db.Model(&models.CheckIn{}).FindInBatches(&batch, m.batchSize, func(tx *gorm.DB, _ int) error {
+		for _, c := range batch {
+			id := int64(c.ID)
+			r.total++
+			if m.migrated[r.source][id] {
+				r.skipped++
+				continue
+			}
+			if !m.checkRefs(r, id, int64(c.UserID), int64(c.SportTypeID)) {
+				r.failed++
+				continue
+			}
+
+			images := splitImages(c.Images)
+			record := &models.SportRecord{
+				UserID:      int64(c.UserID),
+				SportTypeID: int64(c.SportTypeID),
+				Duration:    int64(c.Duration),
+				StartTime:   c.CreatedAt,
+				Notes:       c.Description,
+				ImgURLList:  imageList(images...),
+				Visibility:  c.Visibility,
+				CreatedAt:   c.CreatedAt,
+				UpdatedAt:   c.UpdatedAt,
+			}
+			if len(images) > 0 {
+				record.ImageURL = images[0]
+			}
+			m.normalize(r, id, record)
+			m.save(r, id, record)
+		}
+		return nil
+	}).Error
+	return r, err
+}
+
+// normalize 修正不合法的字段并记录异常
+func (m *migrator) normalize(r *report, id int64, record *models.SportRecord) {
+	if record.Duration <= 0 {
+		r.anomaly(id, "运动时长为 %d", record.Duration)
+		if record.Duration < 0 {
+			record.Duration = 0
+		}
+	}
+	if record.Calories < 0 {
+		r.anomaly(id, "卡路里为负数 %d，已置为0", record.Calories)
+		record.Calories = 0
+	}
+	if record.Distance < 0 {
+		r.anomaly(id, "距离为负数 %.2f，已置为0", record.Distance)
+		record.Distance = 0
+	}
+	if record.StartTime.IsZero() {
+		r.anomaly(id, "缺少运动时间，使用创建时间")
+		record.StartTime = record.CreatedAt
+	}
+	record.EndTime = record.StartTime.Add(time.Duration(record.Duration) * time.Minute)
+	if len(record.ImageURL) > 255 {
+		r.anomaly(id, "图片地址超过255个字符，仅保留在图片列表中")
+		record.ImageURL = ""
+	}
+	if notes := []rune(record.Notes); len(notes) > maxNotesLength {
+		r.anomaly(id, "描述超过%d个字符，已截断", maxNotesLength)
+		record.Notes = string(notes[:maxNotesLength])
+	}
+}
+
+// splitImages 拆分逗号分隔的图片地址
+func splitImages(images string) []string {
+	var urls []string
+	for _, url := range strings.Split(images, ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// imageList 生成 img_url_list 使用的 JSON 数组
+func imageList(urls ...string) string {
+	list := make([]string, 0, len(urls))
+	for _, url := range urls {
+		if url != "" {
+			list = append(list, url)
+		}
+	}
+	data, _ := json.Marshal(list)
+	return string(data)
+}
@@ -98,9 +98,10 @@ func GetConfig() *Config {
 				Name:     getEnv("LOGS_DB_NAME", "sports_app_logs"),
 			},
 			JWT: JWTConfig{
-				PrivateKeyPath: getEnv("JWT_PRIVATE_KEY_PATH", filepath.Join("config", "keys", "private.pem")),
-				PublicKeyPath:  getEnv("JWT_PUBLIC_KEY_PATH", filepath.Join("config", "keys", "public.pem")),
-				ExpiresIn:      int(getEnvInt64("JWT_EXPIRE", 86400)), // 默认24小时
+				PrivateKeyPath:   getEnv("JWT_PRIVATE_KEY_PATH", filepath.Join("config", "keys", "private.pem")),
+				PublicKeyPath:    getEnv("JWT_PUBLIC_KEY_PATH", filepath.Join("config", "keys", "public.pem")),
+				ExpiresIn:        int(getEnvInt64("JWT_EXPIRE", 900)),             // 访问令牌默认15分钟
+				RefreshExpiresIn: int(getEnvInt64("JWT_REFRESH_EXPIRE", 2592000)), // 刷新令牌默认30天
 			},
 			Server: ServerConfig{
 				Port: getEnv("PORT", "8080"),
@@ -196,10 +197,10 @@ func getEnvInt64(key string, defaultValue int64) int64 {
 
 // JWTConfig JWT 配置
 type JWTConfig struct {
-	SecretKey      string `yaml:"secret_key"`
-	ExpiresIn      int    `yaml:"expires_in"`
-	PrivateKeyPath string `yaml:"private_key_path"`
-	PublicKeyPath  string `yaml:"public_key_path"`
+	ExpiresIn        int    `yaml:"expires_in"`         // 访问令牌有效期（秒）
+	RefreshExpiresIn int    `yaml:"refresh_expires_in"` // 刷新令牌有效期（秒），每次刷新后重新计算
+	PrivateKeyPath   string `yaml:"private_key_path"`   // 签名私钥，RSA 使用 RS256，Ed25519 使用 EdDSA
+	PublicKeyPath    string `yaml:"public_key_path"`    // 校验公钥，可包含多个 PEM 公钥，轮换期间保留旧公钥
 }
 
 // LoadConfig 从配置文件加载配置
@@ -0,0 +1,25 @@
+package config
+
+import "path/filepath"
+
+// ModerationConfig 内容审核配置
+type ModerationConfig struct {
+	WordListPath   string // 敏感词表路径
+	CheckInPolicy  string // 打卡描述的处理策略：reject/mask/review
+	CommentPolicy  string // 评论的处理策略
+	UsernamePolicy string // 用户名的处理策略
+	MessagePolicy  string // 私信的处理策略
+	GroupPolicy    string // 群组和活动资料的处理策略
+}
+
+// LoadModerationConfig 从环境变量加载内容审核配置，未设置时使用默认值
+func LoadModerationConfig() *ModerationConfig {
+	return &ModerationConfig{
+		WordListPath:   getEnv("SENSITIVE_WORDS_PATH", filepath.Join("config", "sensitive_words.txt")),
+		CheckInPolicy:  getEnv("MODERATION_CHECK_IN_POLICY", "mask"),
+		CommentPolicy:  getEnv("MODERATION_COMMENT_POLICY", "mask"),
+		UsernamePolicy: getEnv("MODERATION_USERNAME_POLICY", "reject"),
+		MessagePolicy:  getEnv("MODERATION_MESSAGE_POLICY", "mask"),
+		GroupPolicy:    getEnv("MODERATION_GROUP_POLICY", "mask"),
+	}
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ShareConfig 分享链接配置
+type ShareConfig struct {
+	BaseURL      string // 分享页的站点地址，用于拼接分享链接和 og:url
+	CardFontPath string // 分享卡片使用的中文字体，支持 ttf/otf/ttc
+	CardIconDir  string // 运动类型图标目录，文件名为 sport_types.icon 加 .png 后缀
+}
+
+// LoadShareConfig 从环境变量加载分享链接配置，未设置时使用默认值
+func LoadShareConfig() *ShareConfig {
+	return &ShareConfig{
+		BaseURL:      strings.TrimRight(getEnv("SHARE_BASE_URL", "https://www.redamancy.com.cn"), "/"),
+		CardFontPath: getEnv("SHARE_CARD_FONT", filepath.Join("static", "fonts", "NotoSansSC-Regular.ttf")),
+		CardIconDir:  getEnv("SHARE_CARD_ICON_DIR", filepath.Join("static", "sport-icons")),
+	}
+}
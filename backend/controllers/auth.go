@@ -1,19 +1,22 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"sports-app/backend/models"
 	"sports-app/backend/services"
+	"sports-app/backend/token"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AuthController struct {
-	service *services.AuthService
+	service  *services.AuthService
+	sessions *services.SessionService
 }
 
-func NewAuthController(service *services.AuthService) *AuthController {
-	return &AuthController{service: service}
+func NewAuthController(service *services.AuthService, sessions *services.SessionService) *AuthController {
+	return &AuthController{service: service, sessions: sessions}
 }
 
 func (c *AuthController) Register(ctx *gin.Context) {
@@ -48,14 +51,17 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 	
-	token, err := services.GenerateToken(user.ID)
+	pair, err := c.sessions.CreateSession(user.ID, ctx.Request.UserAgent(), ctx.ClientIP())
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "生成token失败"})
 		return
 	}
 	
 	ctx.JSON(http.StatusOK, gin.H{
-		"token": token,
+		"token":              pair.Token,
+		"expires_at":         pair.ExpiresAt,
+		"refresh_token":      pair.RefreshToken,
+		"refresh_expires_at": pair.RefreshExpiresAt,
 		"user": gin.H{
 			"id":       user.ID,
 			"username": user.Username,
@@ -64,10 +70,61 @@ func (c *AuthController) Login(ctx *gin.Context) {
 	})
 }
 
+// Refresh 使用刷新令牌换取新的令牌，旧刷新令牌随即失效
+func (c *AuthController) Refresh(ctx *gin.Context) {
+	var req struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "无效的请求参数"})
+		return
+	}
+
+	pair, err := c.sessions.Refresh(req.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrRefreshTokenReused):
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error(), "code": "REFRESH_TOKEN_REUSED"})
+		case errors.Is(err, services.ErrInvalidRefreshToken), errors.Is(err, services.ErrSessionRevoked):
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error(), "code": "INVALID_REFRESH_TOKEN"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "刷新token失败"})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, pair)
+}
+
+// Logout 登出当前设备，吊销当前会话
 func (c *AuthController) Logout(ctx *gin.Context) {
+	if err := c.sessions.Revoke(ctx.GetInt64("user_id"), ctx.GetUint64("session_id")); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "登出失败"})
+		return
+	}
 	ctx.JSON(http.StatusOK, gin.H{"message": "登出成功"})
 }
 
+// LogoutAll 退出所有设备，吊销用户的全部会话
+func (c *AuthController) LogoutAll(ctx *gin.Context) {
+	revoked, err := c.sessions.RevokeAll(ctx.GetInt64("user_id"), models.SessionRevokeLogoutAll)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "登出失败"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "已退出所有设备", "revoked": revoked})
+}
+
+// JWKS 发布访问令牌的校验公钥，包含轮换期间仍然有效的旧公钥
+func (c *AuthController) JWKS(ctx *gin.Context) {
+	manager, err := token.Default()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "加载签名密钥失败"})
+		return
+	}
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, manager.JWKS())
+}
+
 // ResetPassword 发送重置密码邮件
 func (c *AuthController) ResetPassword(ctx *gin.Context) {
 	var req struct {
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"net/http"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BlockController 屏蔽与静音控制器
+type BlockController struct {
+	service *services.BlockService
+}
+
+// NewBlockController 创建屏蔽控制器实例
+func NewBlockController(service *services.BlockService) *BlockController {
+	return &BlockController{service: service}
+}
+
+// Block 屏蔽用户，重复请求幂等
+func (c *BlockController) Block(ctx *gin.Context) {
+	c.handle(ctx, c.service.Block, gin.H{"blocking": true})
+}
+
+// Unblock 取消屏蔽，重复请求幂等
+func (c *BlockController) Unblock(ctx *gin.Context) {
+	c.handle(ctx, c.service.Unblock, gin.H{"blocking": false})
+}
+
+// Mute 静音用户，重复请求幂等
+func (c *BlockController) Mute(ctx *gin.Context) {
+	c.handle(ctx, c.service.Mute, gin.H{"muting": true})
+}
+
+// Unmute 取消静音，重复请求幂等
+func (c *BlockController) Unmute(ctx *gin.Context) {
+	c.handle(ctx, c.service.Unmute, gin.H{"muting": false})
+}
+
+// handle 解析路径中的用户ID并执行屏蔽或静音操作
+func (c *BlockController) handle(ctx *gin.Context, action func(userID, targetID int64) error, result gin.H) {
+	targetID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := action(ctx.GetInt64("user_id"), targetID); err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GetBlocks 获取屏蔽列表
+func (c *BlockController) GetBlocks(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+	blocks, total, err := c.service.GetBlocks(ctx.GetInt64("user_id"), page, pageSize)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	respondPage(ctx, blocks, total, page, pageSize)
+}
+
+// GetMutes 获取静音列表
+func (c *BlockController) GetMutes(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+	mutes, total, err := c.service.GetMutes(ctx.GetInt64("user_id"), page, pageSize)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	respondPage(ctx, mutes, total, page, pageSize)
+}
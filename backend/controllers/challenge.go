@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ChallengeController 挑战控制器
+type ChallengeController struct {
+	service *services.ChallengeService
+}
+
+// NewChallengeController 创建挑战控制器实例
+func NewChallengeController(service *services.ChallengeService) *ChallengeController {
+	return &ChallengeController{service: service}
+}
+
+// challengeID 解析路径中的挑战ID
+func challengeID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid challenge ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// CreateChallenge 创建挑战
+func (c *ChallengeController) CreateChallenge(ctx *gin.Context) {
+	var input services.CreateChallengeInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	challenge, err := c.service.CreateChallenge(ctx.GetInt64("user_id"), input)
+	if err != nil {
+		respondChallengeError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, challenge)
+}
+
+// GetChallenges 获取挑战列表
+func (c *ChallengeController) GetChallenges(ctx *gin.Context) {
+	var filter services.ChallengeFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	challenges, total, err := c.service.ListChallenges(ctx.GetInt64("user_id"), filter, page, pageSize)
+	if err != nil {
+		respondChallengeError(ctx, err)
+		return
+	}
+	respondPage(ctx, challenges, total, page, pageSize)
+}
+
+// GetChallenge 获取挑战详情
+func (c *ChallengeController) GetChallenge(ctx *gin.Context) {
+	id, ok := challengeID(ctx)
+	if !ok {
+		return
+	}
+
+	challenge, err := c.service.GetChallenge(ctx.GetInt64("user_id"), id)
+	if err != nil {
+		respondChallengeError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, challenge)
+}
+
+// DeleteChallenge 删除挑战
+func (c *ChallengeController) DeleteChallenge(ctx *gin.Context) {
+	id, ok := challengeID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.DeleteChallenge(ctx.GetInt64("user_id"), id); err != nil {
+		respondChallengeError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "挑战已删除"})
+}
+
+// JoinChallenge 参加挑战
+func (c *ChallengeController) JoinChallenge(ctx *gin.Context) {
+	id, ok := challengeID(ctx)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		GroupID *uint64 `json:"group_id"`
+	}
+	// 请求体可选，团队挑战需要 group_id
+	_ = ctx.ShouldBindJSON(&input)
+
+	participant, err := c.service.JoinChallenge(ctx.GetInt64("user_id"), id, input.GroupID)
+	if err != nil {
+		respondChallengeError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, participant)
+}
+
+// LeaveChallenge 退出挑战
+func (c *ChallengeController) LeaveChallenge(ctx *gin.Context) {
+	id, ok := challengeID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.LeaveChallenge(ctx.GetInt64("user_id"), id); err != nil {
+		respondChallengeError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "已退出挑战"})
+}
+
+// GetLeaderboard 获取个人排行榜
+func (c *ChallengeController) GetLeaderboard(ctx *gin.Context) {
+	id, ok := challengeID(ctx)
+	if !ok {
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	participants, total, err := c.service.GetLeaderboard(ctx.GetInt64("user_id"), id, page, pageSize)
+	if err != nil {
+		respondChallengeError(ctx, err)
+		return
+	}
+	respondPage(ctx, participants, total, page, pageSize)
+}
+
+// GetTeamLeaderboard 获取团队排行榜
+func (c *ChallengeController) GetTeamLeaderboard(ctx *gin.Context) {
+	id, ok := challengeID(ctx)
+	if !ok {
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	teams, total, err := c.service.GetTeamLeaderboard(id, page, pageSize)
+	if err != nil {
+		respondChallengeError(ctx, err)
+		return
+	}
+	respondPage(ctx, teams, total, page, pageSize)
+}
+
+// respondChallengeError 根据错误类型返回对应的状态码
+func respondChallengeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidChallenge), errors.Is(err, services.ErrSensitiveContent):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "挑战或群组不存在"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
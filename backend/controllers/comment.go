@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"net/http"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CommentController 评论控制器
+type CommentController struct {
+	service *services.CommentService
+}
+
+// NewCommentController 创建评论控制器实例
+func NewCommentController(service *services.CommentService) *CommentController {
+	return &CommentController{service: service}
+}
+
+// ListComments 获取打卡的评论，mode=flat 时平铺回复，默认树形
+func (c *CommentController) ListComments(ctx *gin.Context) {
+	checkInID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid check-in ID"})
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	flat := ctx.Query("mode") == "flat"
+	comments, total, err := c.service.ListComments(ctx.GetInt64("user_id"), checkInID, page, pageSize, flat)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	respondPage(ctx, comments, total, page, pageSize)
+}
+
+// CreateComment 发表评论或回复
+func (c *CommentController) CreateComment(ctx *gin.Context) {
+	checkInID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid check-in ID"})
+		return
+	}
+
+	var req struct {
+		Content  string  `json:"content" binding:"required"`
+		ParentID *uint64 `json:"parent_id"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := c.service.CreateComment(ctx.GetInt64("user_id"), checkInID, req.Content, req.ParentID)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, comment)
+}
+
+// UpdateComment 编辑评论
+func (c *CommentController) UpdateComment(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	var req struct {
+		Content string `json:"content" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := c.service.UpdateComment(ctx.GetInt64("user_id"), id, req.Content)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment 删除评论
+func (c *CommentController) DeleteComment(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	if err := c.service.DeleteComment(ctx.GetInt64("user_id"), id); err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "评论已删除"})
+}
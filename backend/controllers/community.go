@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CommunityController 社区打卡控制器
+type CommunityController struct {
+	service *services.CommunityService
+}
+
+// NewCommunityController 创建社区打卡控制器实例
+func NewCommunityController(service *services.CommunityService) *CommunityController {
+	return &CommunityController{service: service}
+}
+
+// CreateCheckIn 发布打卡
+func (c *CommunityController) CreateCheckIn(ctx *gin.Context) {
+	var input services.CreateCheckInInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	checkIn, err := c.service.CreateCheckIn(ctx.GetInt64("user_id"), input)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, checkIn)
+}
+
+// GetCheckIn 获取单条打卡
+func (c *CommunityController) GetCheckIn(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid check-in ID"})
+		return
+	}
+
+	checkIn, err := c.service.GetCheckIn(ctx.GetInt64("user_id"), id)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, checkIn)
+}
+
+// DeleteCheckIn 删除打卡
+func (c *CommunityController) DeleteCheckIn(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid check-in ID"})
+		return
+	}
+
+	if err := c.service.DeleteCheckIn(ctx.GetInt64("user_id"), id); err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "打卡已删除"})
+}
+
+// GetFeed 获取全站动态
+func (c *CommunityController) GetFeed(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+	checkIns, total, err := c.service.GetFeed(ctx.GetInt64("user_id"), page, pageSize)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	respondPage(ctx, checkIns, total, page, pageSize)
+}
+
+// GetUserFeed 获取某个用户的动态
+func (c *CommunityController) GetUserFeed(ctx *gin.Context) {
+	userID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	checkIns, total, err := c.service.GetUserFeed(ctx.GetInt64("user_id"), userID, page, pageSize)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	respondPage(ctx, checkIns, total, page, pageSize)
+}
+
+// GetFollowingFeed 获取关注用户的动态，使用游标分页
+func (c *CommunityController) GetFollowingFeed(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	checkIns, nextCursor, err := c.service.GetFollowingFeed(ctx.GetInt64("user_id"), ctx.Query("cursor"), limit)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": checkIns,
+		"meta": gin.H{
+			"next_cursor": nextCursor,
+			"has_more":    nextCursor != "",
+		},
+	})
+}
+
+// pageParams 读取分页参数
+func pageParams(ctx *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	return page, pageSize
+}
+
+// respondPage 返回分页数据
+func respondPage(ctx *gin.Context, data interface{}, total int64, page, pageSize int) {
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"meta": gin.H{
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+	})
+}
+
+// respondCommunityError 根据错误类型返回对应的状态码
+func respondCommunityError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidCheckIn), errors.Is(err, services.ErrInvalidComment),
+		errors.Is(err, services.ErrInvalidFollow), errors.Is(err, services.ErrInvalidBlock),
+		errors.Is(err, services.ErrSensitiveContent):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "内容不存在"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,226 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// EventController 活动控制器
+type EventController struct {
+	service *services.EventService
+}
+
+// NewEventController 创建活动控制器实例
+func NewEventController(service *services.EventService) *EventController {
+	return &EventController{service: service}
+}
+
+// eventID 解析路径中的活动ID
+func eventID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// CreateEvent 创建活动
+func (c *EventController) CreateEvent(ctx *gin.Context) {
+	var input services.EventInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	event, err := c.service.CreateEvent(ctx.GetInt64("user_id"), input)
+	if err != nil {
+		respondEventError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, event)
+}
+
+// GetEvents 获取活动列表
+func (c *EventController) GetEvents(ctx *gin.Context) {
+	var filter services.EventFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	events, total, err := c.service.ListEvents(ctx.GetInt64("user_id"), filter, page, pageSize)
+	if err != nil {
+		respondEventError(ctx, err)
+		return
+	}
+	respondPage(ctx, events, total, page, pageSize)
+}
+
+// GetEvent 获取活动详情
+func (c *EventController) GetEvent(ctx *gin.Context) {
+	id, ok := eventID(ctx)
+	if !ok {
+		return
+	}
+
+	event, err := c.service.GetEvent(ctx.GetInt64("user_id"), id)
+	if err != nil {
+		respondEventError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, event)
+}
+
+// UpdateEvent 修改活动
+func (c *EventController) UpdateEvent(ctx *gin.Context) {
+	id, ok := eventID(ctx)
+	if !ok {
+		return
+	}
+
+	var input services.EventInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	event, err := c.service.UpdateEvent(ctx.GetInt64("user_id"), id, input)
+	if err != nil {
+		respondEventError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, event)
+}
+
+// CancelEvent 取消活动
+func (c *EventController) CancelEvent(ctx *gin.Context) {
+	id, ok := eventID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.CancelEvent(ctx.GetInt64("user_id"), id); err != nil {
+		respondEventError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "活动已取消"})
+}
+
+// RSVP 报名活动
+func (c *EventController) RSVP(ctx *gin.Context) {
+	id, ok := eventID(ctx)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Status string `json:"status" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rsvp, err := c.service.RSVP(ctx.GetInt64("user_id"), id, input.Status)
+	if err != nil {
+		respondEventError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rsvp)
+}
+
+// GetAttendees 获取活动报名列表
+func (c *EventController) GetAttendees(ctx *gin.Context) {
+	id, ok := eventID(ctx)
+	if !ok {
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	rsvps, total, err := c.service.GetAttendees(ctx.GetInt64("user_id"), id, ctx.Query("status"), page, pageSize)
+	if err != nil {
+		respondEventError(ctx, err)
+		return
+	}
+	respondPage(ctx, rsvps, total, page, pageSize)
+}
+
+// MarkAttendance 确认到场情况
+func (c *EventController) MarkAttendance(ctx *gin.Context) {
+	id, ok := eventID(ctx)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Attendees []services.AttendanceInput `json:"attendees" binding:"required,dive"`
+	}
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.service.MarkAttendance(ctx.GetInt64("user_id"), id, input.Attendees); err != nil {
+		respondEventError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "签到已更新"})
+}
+
+// LinkRecord 将运动记录关联到活动
+func (c *EventController) LinkRecord(ctx *gin.Context) {
+	id, ok := eventID(ctx)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		RecordID int64 `json:"record_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rsvp, err := c.service.LinkRecord(ctx.GetInt64("user_id"), id, input.RecordID)
+	if err != nil {
+		respondEventError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rsvp)
+}
+
+// UnlinkRecord 解除活动关联的运动记录
+func (c *EventController) UnlinkRecord(ctx *gin.Context) {
+	id, ok := eventID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.UnlinkRecord(ctx.GetInt64("user_id"), id); err != nil {
+		respondEventError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "已解除关联"})
+}
+
+// respondEventError 根据错误类型返回对应的状态码
+func respondEventError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidEvent), errors.Is(err, services.ErrSensitiveContent):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "活动或报名不存在"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"net/http"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FollowController 用户关注控制器
+type FollowController struct {
+	service *services.FollowService
+}
+
+// NewFollowController 创建关注控制器实例
+func NewFollowController(service *services.FollowService) *FollowController {
+	return &FollowController{service: service}
+}
+
+// Follow 关注用户，重复请求幂等
+func (c *FollowController) Follow(ctx *gin.Context) {
+	followeeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := c.service.Follow(ctx.GetInt64("user_id"), followeeID); err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"following": true})
+}
+
+// Unfollow 取消关注，重复请求幂等
+func (c *FollowController) Unfollow(ctx *gin.Context) {
+	followeeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := c.service.Unfollow(ctx.GetInt64("user_id"), followeeID); err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"following": false})
+}
+
+// GetFollowers 获取粉丝列表
+func (c *FollowController) GetFollowers(ctx *gin.Context) {
+	userID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	follows, total, err := c.service.GetFollowers(ctx.GetInt64("user_id"), userID, page, pageSize)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	respondPage(ctx, follows, total, page, pageSize)
+}
+
+// GetFollowing 获取关注列表
+func (c *FollowController) GetFollowing(ctx *gin.Context) {
+	userID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	follows, total, err := c.service.GetFollowing(ctx.GetInt64("user_id"), userID, page, pageSize)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	respondPage(ctx, follows, total, page, pageSize)
+}
+
+// GetUserProfile 获取其他用户的公开资料及关注数据
+func (c *FollowController) GetUserProfile(ctx *gin.Context) {
+	userID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	profile, err := c.service.GetUserProfile(ctx.GetInt64("user_id"), userID)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, profile)
+}
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sports-app/backend/models"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GearController 运动装备控制器
+type GearController struct {
+	service *services.GearService
+}
+
+// NewGearController 创建运动装备控制器实例
+func NewGearController(service *services.GearService) *GearController {
+	return &GearController{service: service}
+}
+
+// GetGear 获取装备列表，include_retired=true 时包含已退役装备
+func (c *GearController) GetGear(ctx *gin.Context) {
+	includeRetired := ctx.Query("include_retired") == "true"
+	gear, err := c.service.GetGear(ctx.GetInt64("user_id"), includeRetired)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gear)
+}
+
+// GetGearByID 获取单件装备
+func (c *GearController) GetGearByID(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid gear ID"})
+		return
+	}
+
+	gear, err := c.service.GetGearByID(ctx.GetInt64("user_id"), id)
+	if err != nil {
+		respondGearError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gear)
+}
+
+// CreateGear 创建装备
+func (c *GearController) CreateGear(ctx *gin.Context) {
+	var gear models.Gear
+	if err := ctx.ShouldBindJSON(&gear); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	gear.UserID = ctx.GetInt64("user_id")
+	if err := c.service.CreateGear(&gear); err != nil {
+		respondGearError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, gear)
+}
+
+// UpdateGear 更新装备
+func (c *GearController) UpdateGear(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid gear ID"})
+		return
+	}
+
+	var gear models.Gear
+	if err := ctx.ShouldBindJSON(&gear); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	gear.ID = id
+	gear.UserID = ctx.GetInt64("user_id")
+	if err := c.service.UpdateGear(&gear); err != nil {
+		respondGearError(ctx, err)
+		return
+	}
+
+	updated, err := c.service.GetGearByID(gear.UserID, id)
+	if err != nil {
+		respondGearError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, updated)
+}
+
+// DeleteGear 删除装备
+func (c *GearController) DeleteGear(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid gear ID"})
+		return
+	}
+
+	if err := c.service.DeleteGear(ctx.GetInt64("user_id"), id); err != nil {
+		respondGearError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetGearStats 获取单件装备的统计信息
+func (c *GearController) GetGearStats(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid gear ID"})
+		return
+	}
+
+	stats, err := c.service.GetGearStats(ctx.GetInt64("user_id"), id)
+	if err != nil {
+		respondGearError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, stats)
+}
+
+// respondGearError 根据错误类型返回对应的状态码
+func respondGearError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidGear):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "装备不存在"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
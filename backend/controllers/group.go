@@ -0,0 +1,349 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GroupController 群组控制器
+type GroupController struct {
+	service *services.GroupService
+}
+
+// NewGroupController 创建群组控制器实例
+func NewGroupController(service *services.GroupService) *GroupController {
+	return &GroupController{service: service}
+}
+
+// groupID 解析路径中的群组ID
+func groupID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// CreateGroup 创建群组
+func (c *GroupController) CreateGroup(ctx *gin.Context) {
+	var input services.GroupInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := c.service.CreateGroup(ctx.GetInt64("user_id"), input)
+	if err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, group)
+}
+
+// GetGroups 搜索群组，q 为名称关键字
+func (c *GroupController) GetGroups(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+	groups, total, err := c.service.ListGroups(ctx.GetInt64("user_id"), ctx.Query("q"), page, pageSize)
+	if err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	respondPage(ctx, groups, total, page, pageSize)
+}
+
+// GetMyGroups 获取已加入的群组
+func (c *GroupController) GetMyGroups(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+	groups, total, err := c.service.GetMyGroups(ctx.GetInt64("user_id"), page, pageSize)
+	if err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	respondPage(ctx, groups, total, page, pageSize)
+}
+
+// GetGroup 获取群组详情
+func (c *GroupController) GetGroup(ctx *gin.Context) {
+	id, ok := groupID(ctx)
+	if !ok {
+		return
+	}
+
+	group, err := c.service.GetGroup(ctx.GetInt64("user_id"), id)
+	if err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, group)
+}
+
+// UpdateGroup 修改群组资料
+func (c *GroupController) UpdateGroup(ctx *gin.Context) {
+	id, ok := groupID(ctx)
+	if !ok {
+		return
+	}
+
+	var input services.GroupInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := c.service.UpdateGroup(ctx.GetInt64("user_id"), id, input)
+	if err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, group)
+}
+
+// DeleteGroup 解散群组
+func (c *GroupController) DeleteGroup(ctx *gin.Context) {
+	id, ok := groupID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.DeleteGroup(ctx.GetInt64("user_id"), id); err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "群组已解散"})
+}
+
+// JoinGroup 加入群组或提交入群申请
+func (c *GroupController) JoinGroup(ctx *gin.Context) {
+	id, ok := groupID(ctx)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Message string `json:"message"`
+	}
+	// 请求体可选
+	_ = ctx.ShouldBindJSON(&input)
+
+	joined, err := c.service.JoinGroup(ctx.GetInt64("user_id"), id, input.Message)
+	if err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	if !joined {
+		ctx.JSON(http.StatusAccepted, gin.H{"joined": false, "message": "已提交入群申请，等待管理员审批"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"joined": true, "message": "已加入群组"})
+}
+
+// JoinByInviteCode 通过邀请码加入群组
+func (c *GroupController) JoinByInviteCode(ctx *gin.Context) {
+	var input struct {
+		InviteCode string `json:"invite_code" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := c.service.JoinByInviteCode(ctx.GetInt64("user_id"), input.InviteCode)
+	if err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, group)
+}
+
+// LeaveGroup 退出群组
+func (c *GroupController) LeaveGroup(ctx *gin.Context) {
+	id, ok := groupID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.LeaveGroup(ctx.GetInt64("user_id"), id); err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "已退出群组"})
+}
+
+// GetMembers 获取群组成员列表
+func (c *GroupController) GetMembers(ctx *gin.Context) {
+	id, ok := groupID(ctx)
+	if !ok {
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	members, total, err := c.service.GetMembers(ctx.GetInt64("user_id"), id, page, pageSize)
+	if err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	respondPage(ctx, members, total, page, pageSize)
+}
+
+// UpdateMemberRole 修改成员角色或转让群组
+func (c *GroupController) UpdateMemberRole(ctx *gin.Context) {
+	id, ok := groupID(ctx)
+	if !ok {
+		return
+	}
+	userID, err := strconv.ParseInt(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var input struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.service.UpdateMemberRole(ctx.GetInt64("user_id"), id, userID, input.Role); err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "成员角色已更新"})
+}
+
+// RemoveMember 移除群组成员
+func (c *GroupController) RemoveMember(ctx *gin.Context) {
+	id, ok := groupID(ctx)
+	if !ok {
+		return
+	}
+	userID, err := strconv.ParseInt(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := c.service.RemoveMember(ctx.GetInt64("user_id"), id, userID); err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "成员已移除"})
+}
+
+// ResetInviteCode 重新生成邀请码
+func (c *GroupController) ResetInviteCode(ctx *gin.Context) {
+	id, ok := groupID(ctx)
+	if !ok {
+		return
+	}
+
+	code, err := c.service.ResetInviteCode(ctx.GetInt64("user_id"), id)
+	if err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"invite_code": code})
+}
+
+// GetJoinRequests 获取入群申请列表
+func (c *GroupController) GetJoinRequests(ctx *gin.Context) {
+	id, ok := groupID(ctx)
+	if !ok {
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	requests, total, err := c.service.GetJoinRequests(ctx.GetInt64("user_id"), id, ctx.Query("status"), page, pageSize)
+	if err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	respondPage(ctx, requests, total, page, pageSize)
+}
+
+// ApproveJoinRequest 通过入群申请
+func (c *GroupController) ApproveJoinRequest(ctx *gin.Context) {
+	c.handleJoinRequest(ctx, true)
+}
+
+// RejectJoinRequest 拒绝入群申请
+func (c *GroupController) RejectJoinRequest(ctx *gin.Context) {
+	c.handleJoinRequest(ctx, false)
+}
+
+// handleJoinRequest 审批入群申请
+func (c *GroupController) handleJoinRequest(ctx *gin.Context, approve bool) {
+	id, ok := groupID(ctx)
+	if !ok {
+		return
+	}
+	requestID, err := strconv.ParseUint(ctx.Param("request_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+
+	if err := c.service.HandleJoinRequest(ctx.GetInt64("user_id"), id, requestID, approve); err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "申请已处理"})
+}
+
+// GetGroupFeed 获取群组成员的打卡动态
+func (c *GroupController) GetGroupFeed(ctx *gin.Context) {
+	id, ok := groupID(ctx)
+	if !ok {
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	checkIns, total, err := c.service.GetGroupFeed(ctx.GetInt64("user_id"), id, page, pageSize)
+	if err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	respondPage(ctx, checkIns, total, page, pageSize)
+}
+
+// GetGroupStats 获取群组运动数据汇总
+func (c *GroupController) GetGroupStats(ctx *gin.Context) {
+	id, ok := groupID(ctx)
+	if !ok {
+		return
+	}
+
+	var filter services.GroupStatsFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	stats, err := c.service.GetGroupStats(ctx.GetInt64("user_id"), id, filter)
+	if err != nil {
+		respondGroupError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, stats)
+}
+
+// respondGroupError 根据错误类型返回对应的状态码
+func respondGroupError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidGroup), errors.Is(err, services.ErrSensitiveContent):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "群组或成员不存在"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
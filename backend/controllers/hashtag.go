@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// HashtagController 话题控制器
+type HashtagController struct {
+	service *services.HashtagService
+}
+
+// NewHashtagController 创建话题控制器实例
+func NewHashtagController(service *services.HashtagService) *HashtagController {
+	return &HashtagController{service: service}
+}
+
+// GetTagFeed 获取话题下的打卡动态
+func (c *HashtagController) GetTagFeed(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+	checkIns, total, err := c.service.GetTagFeed(ctx.GetInt64("user_id"), ctx.Param("name"), page, pageSize)
+	if err != nil {
+		respondHashtagError(ctx, err)
+		return
+	}
+	respondPage(ctx, checkIns, total, page, pageSize)
+}
+
+// GetTrendingTags 获取热门话题
+func (c *HashtagController) GetTrendingTags(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+	tags, err := c.service.GetTrendingTags(ctx.Query("window"), limit)
+	if err != nil {
+		respondHashtagError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, tags)
+}
+
+// respondHashtagError 根据错误类型返回对应的状态码
+func respondHashtagError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidHashtag):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "话题不存在"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
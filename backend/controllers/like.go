@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"net/http"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LikeController 点赞控制器
+type LikeController struct {
+	service *services.LikeService
+}
+
+// NewLikeController 创建点赞控制器实例
+func NewLikeController(service *services.LikeService) *LikeController {
+	return &LikeController{service: service}
+}
+
+// Like 点赞打卡，重复请求幂等
+func (c *LikeController) Like(ctx *gin.Context) {
+	checkInID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid check-in ID"})
+		return
+	}
+
+	state, err := c.service.Like(ctx.GetInt64("user_id"), checkInID)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, state)
+}
+
+// Unlike 取消点赞，重复请求幂等
+func (c *LikeController) Unlike(ctx *gin.Context) {
+	checkInID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid check-in ID"})
+		return
+	}
+
+	state, err := c.service.Unlike(ctx.GetInt64("user_id"), checkInID)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, state)
+}
+
+// ListLikes 获取点赞用户列表
+func (c *LikeController) ListLikes(ctx *gin.Context) {
+	checkInID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid check-in ID"})
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	likes, total, err := c.service.ListLikes(ctx.GetInt64("user_id"), checkInID, page, pageSize)
+	if err != nil {
+		respondCommunityError(ctx, err)
+		return
+	}
+	respondPage(ctx, likes, total, page, pageSize)
+}
@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// MessageController 私信控制器
+type MessageController struct {
+	service *services.MessageService
+}
+
+// NewMessageController 创建私信控制器实例
+func NewMessageController(service *services.MessageService) *MessageController {
+	return &MessageController{service: service}
+}
+
+// OpenConversation 获取或创建与某个用户的会话
+func (c *MessageController) OpenConversation(ctx *gin.Context) {
+	var input struct {
+		UserID int64 `json:"user_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conversation, err := c.service.OpenConversation(ctx.GetInt64("user_id"), input.UserID)
+	if err != nil {
+		respondMessageError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, conversation)
+}
+
+// GetConversations 获取会话列表
+func (c *MessageController) GetConversations(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+	conversations, total, err := c.service.GetConversations(ctx.GetInt64("user_id"), page, pageSize)
+	if err != nil {
+		respondMessageError(ctx, err)
+		return
+	}
+	respondPage(ctx, conversations, total, page, pageSize)
+}
+
+// GetConversation 获取单个会话
+func (c *MessageController) GetConversation(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid conversation ID"})
+		return
+	}
+
+	conversation, err := c.service.GetConversation(ctx.GetInt64("user_id"), id)
+	if err != nil {
+		respondMessageError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, conversation)
+}
+
+// GetMessages 获取会话的历史私信，使用游标分页
+func (c *MessageController) GetMessages(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid conversation ID"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	messages, nextCursor, err := c.service.GetMessages(ctx.GetInt64("user_id"), id, ctx.Query("cursor"), limit)
+	if err != nil {
+		respondMessageError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"data": messages,
+		"meta": gin.H{
+			"next_cursor": nextCursor,
+			"has_more":    nextCursor != "",
+		},
+	})
+}
+
+// SendMessage 发送私信
+func (c *MessageController) SendMessage(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid conversation ID"})
+		return
+	}
+
+	var input struct {
+		Content string `json:"content" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	message, err := c.service.SendMessage(ctx.GetInt64("user_id"), id, input.Content)
+	if err != nil {
+		respondMessageError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, message)
+}
+
+// MarkRead 将会话标记为已读
+func (c *MessageController) MarkRead(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid conversation ID"})
+		return
+	}
+
+	if err := c.service.MarkRead(ctx.GetInt64("user_id"), id); err != nil {
+		respondMessageError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "已标记为已读"})
+}
+
+// GetUnreadCount 获取未读私信总数
+func (c *MessageController) GetUnreadCount(ctx *gin.Context) {
+	count, err := c.service.GetUnreadCount(ctx.GetInt64("user_id"))
+	if err != nil {
+		respondMessageError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"unread_count": count})
+}
+
+// GetSettings 获取私信权限设置
+func (c *MessageController) GetSettings(ctx *gin.Context) {
+	privacy, err := c.service.GetPrivacy(ctx.GetInt64("user_id"))
+	if err != nil {
+		respondMessageError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message_privacy": privacy})
+}
+
+// UpdateSettings 更新私信权限设置
+func (c *MessageController) UpdateSettings(ctx *gin.Context) {
+	var input struct {
+		MessagePrivacy string `json:"message_privacy" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.service.UpdatePrivacy(ctx.GetInt64("user_id"), input.MessagePrivacy); err != nil {
+		respondMessageError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message_privacy": input.MessagePrivacy})
+}
+
+// respondMessageError 根据错误类型返回对应的状态码
+func respondMessageError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidMessage), errors.Is(err, services.ErrSensitiveContent):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "会话或用户不存在"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
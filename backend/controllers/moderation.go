@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ModerationController 举报与管理员审核控制器
+type ModerationController struct {
+	service *services.ModerationQueueService
+}
+
+// NewModerationController 创建审核控制器实例
+func NewModerationController(service *services.ModerationQueueService) *ModerationController {
+	return &ModerationController{service: service}
+}
+
+// CreateReport 举报打卡、评论或用户
+func (c *ModerationController) CreateReport(ctx *gin.Context) {
+	var input services.CreateReportInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := c.service.CreateReport(ctx.GetInt64("user_id"), input)
+	if err != nil {
+		respondModerationError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, report)
+}
+
+// GetReports 获取举报队列，status 默认为 pending
+func (c *ModerationController) GetReports(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+	reports, total, err := c.service.GetReports(ctx.Query("status"), page, pageSize)
+	if err != nil {
+		respondModerationError(ctx, err)
+		return
+	}
+	respondPage(ctx, reports, total, page, pageSize)
+}
+
+// HandleReport 处理举报，action 为 hide/dismiss/warn/suspend
+func (c *ModerationController) HandleReport(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	var input services.ModerationActionInput
+	if err := ctx.ShouldBindJSON(&input); err != nil && ctx.Request.ContentLength > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := c.service.HandleReport(ctx.GetInt64("user_id"), id, ctx.Param("action"), input)
+	if err != nil {
+		respondModerationError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+// RestoreTarget 恢复被隐藏的内容或解除用户封禁
+func (c *ModerationController) RestoreTarget(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target ID"})
+		return
+	}
+
+	var input services.ModerationActionInput
+	if err := ctx.ShouldBindJSON(&input); err != nil && ctx.Request.ContentLength > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.service.RestoreTarget(ctx.GetInt64("user_id"), ctx.Param("type"), id, input); err != nil {
+		respondModerationError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "已恢复"})
+}
+
+// GetReviews 获取敏感词审核队列，status 默认为 pending
+func (c *ModerationController) GetReviews(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+	reviews, total, err := c.service.GetReviews(ctx.Query("status"), page, pageSize)
+	if err != nil {
+		respondModerationError(ctx, err)
+		return
+	}
+	respondPage(ctx, reviews, total, page, pageSize)
+}
+
+// ApproveReview 敏感词审核通过
+func (c *ModerationController) ApproveReview(ctx *gin.Context) {
+	c.handleReview(ctx, true)
+}
+
+// RejectReview 敏感词审核拒绝
+func (c *ModerationController) RejectReview(ctx *gin.Context) {
+	c.handleReview(ctx, false)
+}
+
+// handleReview 处理敏感词审核
+func (c *ModerationController) handleReview(ctx *gin.Context, approve bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	var input services.ModerationActionInput
+	if err := ctx.ShouldBindJSON(&input); err != nil && ctx.Request.ContentLength > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := c.service.HandleReview(ctx.GetInt64("user_id"), id, approve, input)
+	if err != nil {
+		respondModerationError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, review)
+}
+
+// GetActions 获取审核操作记录，可通过 target_type、target_id 过滤
+func (c *ModerationController) GetActions(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+	targetID, _ := strconv.ParseUint(ctx.Query("target_id"), 10, 64)
+	actions, total, err := c.service.GetActions(ctx.Query("target_type"), targetID, page, pageSize)
+	if err != nil {
+		respondModerationError(ctx, err)
+		return
+	}
+	respondPage(ctx, actions, total, page, pageSize)
+}
+
+// respondModerationError 根据错误类型返回对应的状态码
+func respondModerationError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidReport):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "内容不存在"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
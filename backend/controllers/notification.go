@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// NotificationController 站内通知控制器
+type NotificationController struct {
+	service *services.NotificationService
+}
+
+// NewNotificationController 创建通知控制器实例
+func NewNotificationController(service *services.NotificationService) *NotificationController {
+	return &NotificationController{service: service}
+}
+
+// GetNotifications 获取通知列表，unread=true 时只返回未读通知
+func (c *NotificationController) GetNotifications(ctx *gin.Context) {
+	page, pageSize := pageParams(ctx)
+	unreadOnly := ctx.Query("unread") == "true"
+	notifications, total, err := c.service.GetNotifications(ctx.GetInt64("user_id"), unreadOnly, page, pageSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	respondPage(ctx, notifications, total, page, pageSize)
+}
+
+// GetUnreadCount 获取未读通知数
+func (c *NotificationController) GetUnreadCount(ctx *gin.Context) {
+	count, err := c.service.GetUnreadCount(ctx.GetInt64("user_id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"unread_count": count})
+}
+
+// MarkRead 将单条通知标记为已读
+func (c *NotificationController) MarkRead(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	if err := c.service.MarkRead(ctx.GetInt64("user_id"), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "通知不存在"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "已标记为已读"})
+}
+
+// MarkAllRead 将全部通知标记为已读
+func (c *NotificationController) MarkAllRead(ctx *gin.Context) {
+	updated, err := c.service.MarkAllRead(ctx.GetInt64("user_id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"updated": updated})
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sports-app/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PrivacyController 隐私设置控制器
+type PrivacyController struct {
+	service *services.PrivacyService
+}
+
+// NewPrivacyController 创建隐私设置控制器实例
+func NewPrivacyController(service *services.PrivacyService) *PrivacyController {
+	return &PrivacyController{service: service}
+}
+
+// GetSettings 获取隐私设置
+func (c *PrivacyController) GetSettings(ctx *gin.Context) {
+	settings, err := c.service.GetSettings(ctx.GetInt64("user_id"))
+	if err != nil {
+		respondPrivacyError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings 更新隐私设置
+func (c *PrivacyController) UpdateSettings(ctx *gin.Context) {
+	var input services.PrivacySettings
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := c.service.UpdateSettings(ctx.GetInt64("user_id"), input)
+	if err != nil {
+		respondPrivacyError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// respondPrivacyError 根据错误类型返回对应的状态码
+func respondPrivacyError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidPrivacy):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "用户不存在"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
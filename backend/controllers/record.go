@@ -1,12 +1,14 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"sports-app/backend/models"
 	"sports-app/backend/services"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // RecordController 运动记录控制器
@@ -40,6 +42,10 @@ func (c *RecordController) CreateRecord(ctx *gin.Context) {
 
 	record.UserID = ctx.GetInt64("user_id")
 	if err := c.service.CreateRecord(&record); err != nil {
+		if errors.Is(err, services.ErrInvalidRecord) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -66,6 +72,14 @@ func (c *RecordController) UpdateRecord(ctx *gin.Context) {
 	record.ID = id
 	record.UserID = userID
 	if err := c.service.UpdateRecord(&record); err != nil {
+		if errors.Is(err, services.ErrInvalidRecord) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "记录不存在"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -91,6 +105,32 @@ func (c *RecordController) DeleteRecord(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "记录已删除"})
 }
 
+// ResolveLegacyRecord 通过旧的 exercises/check_ins ID 获取迁移后的运动记录
+func (c *RecordController) ResolveLegacyRecord(ctx *gin.Context) {
+	source := ctx.Param("source")
+	if source != models.LegacySourceExercise && source != models.LegacySourceCheckIn {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid legacy source"})
+		return
+	}
+
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid legacy ID"})
+		return
+	}
+
+	record, err := c.service.ResolveLegacyRecord(ctx.GetInt64("user_id"), source, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "记录不存在"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, record)
+}
+
 // GetSportTypes 获取所有运动类型
 func (c *RecordController) GetSportTypes(ctx *gin.Context) {
 	types, err := c.service.GetSportTypes()
@@ -161,10 +201,19 @@ func (c *RecordController) GetStats(ctx *gin.Context) {
 	userID := ctx.GetInt64("user_id")
 	
 	// 获取查询参数
-	timeRange := ctx.DefaultQuery("time_range", "week")
 	sportTypeID, _ := strconv.ParseInt(ctx.DefaultQuery("sport_type_id", "0"), 10, 64)
+	minRPE, _ := strconv.Atoi(ctx.DefaultQuery("min_rpe", "0"))
+	maxRPE, _ := strconv.Atoi(ctx.DefaultQuery("max_rpe", "0"))
+	filter := services.StatsFilter{
+		TimeRange:   ctx.DefaultQuery("time_range", "week"),
+		SportTypeID: sportTypeID,
+		Environment: ctx.Query("environment"),
+		Mood:        ctx.Query("mood"),
+		MinRPE:      minRPE,
+		MaxRPE:      maxRPE,
+	}
 	
-	stats, err := c.service.GetStats(userID, timeRange, sportTypeID)
+	stats, err := c.service.GetStats(userID, filter)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
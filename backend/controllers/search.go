@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sports-app/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchController 搜索控制器
+type SearchController struct {
+	service *services.SearchService
+}
+
+// NewSearchController 创建搜索控制器实例
+func NewSearchController(service *services.SearchService) *SearchController {
+	return &SearchController{service: service}
+}
+
+// Search 搜索运动记录、打卡、用户和运动类型
+func (c *SearchController) Search(ctx *gin.Context) {
+	var input services.SearchInput
+	if err := ctx.ShouldBindQuery(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, pageSize := pageParams(ctx)
+	result, err := c.service.Search(ctx.GetInt64("user_id"), input, page, pageSize)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearch) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
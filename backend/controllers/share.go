@@ -0,0 +1,244 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"sports-app/backend/models"
+	"sports-app/backend/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// sharePageTemplate 分享页模板，Open Graph 标签用于微信朋友圈等渠道生成预览卡片
+var sharePageTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<meta name="description" content="{{.Description}}">
+<meta property="og:type" content="article">
+<meta property="og:title" content="{{.Title}}">
+<meta property="og:description" content="{{.Description}}">
+<meta property="og:url" content="{{.Record.URL}}">
+<meta property="og:image" content="{{if .Record.Images}}{{index .Record.Images 0}}{{else}}{{.Record.CardURL}}{{end}}">
+<style>
+body{margin:0 auto;max-width:640px;padding:16px;font-family:-apple-system,"PingFang SC","Microsoft YaHei",sans-serif;color:#222}
+.user{color:#666}
+.stats{display:flex;gap:24px;margin:16px 0}
+.stats b{display:block;font-size:22px}
+.images img{width:100%;margin-bottom:8px;border-radius:8px}
+</style>
+</head>
+<body>
+<div class="user">
+<span>{{.Record.User.Username}}</span>
+</div>
+<h1>{{.Headline}}</h1>
+<p>{{.Record.StartTime.Format "2006-01-02 15:04"}}</p>
+<div class="stats">
+{{- if gt .Record.Distance 0.0}}<div><b>{{printf "%.2f" .Record.Distance}}</b>公里</div>{{end}}
+<div><b>{{.Record.Duration}}</b>分钟</div>
+{{- if gt .Record.Calories 0}}<div><b>{{.Record.Calories}}</b>千卡</div>{{end}}
+</div>
+<div class="images">
+{{- range .Record.Images}}
+<img src="{{.}}" alt="" loading="lazy">
+{{- end}}
+</div>
+</body>
+</html>
+`))
+
+// ShareController 运动记录分享控制器
+type ShareController struct {
+	service *services.ShareService
+}
+
+// NewShareController 创建分享控制器实例
+func NewShareController(service *services.ShareService) *ShareController {
+	return &ShareController{service: service}
+}
+
+// shareRecordID 解析路径中的运动记录ID
+func shareRecordID(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid record ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// CreateShare 为运动记录创建分享链接
+func (c *ShareController) CreateShare(ctx *gin.Context) {
+	recordID, ok := shareRecordID(ctx)
+	if !ok {
+		return
+	}
+
+	var input services.CreateShareInput
+	// 请求体可选，不传时链接长期有效；格式错误时不能忽略，否则会生成长期有效的链接
+	if err := ctx.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	share, err := c.service.CreateShare(ctx.GetInt64("user_id"), recordID, input)
+	if err != nil {
+		respondShareError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, share)
+}
+
+// GetShares 获取运动记录当前有效的分享链接
+func (c *ShareController) GetShares(ctx *gin.Context) {
+	recordID, ok := shareRecordID(ctx)
+	if !ok {
+		return
+	}
+
+	shares, err := c.service.GetShares(ctx.GetInt64("user_id"), recordID)
+	if err != nil {
+		respondShareError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, shares)
+}
+
+// RevokeShare 撤销分享链接
+func (c *ShareController) RevokeShare(ctx *gin.Context) {
+	recordID, ok := shareRecordID(ctx)
+	if !ok {
+		return
+	}
+	shareID, err := strconv.ParseUint(ctx.Param("share_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid share ID"})
+		return
+	}
+
+	if err := c.service.RevokeShare(ctx.GetInt64("user_id"), recordID, shareID); err != nil {
+		respondShareError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "分享链接已撤销"})
+}
+
+// GetSharedRecord 通过分享 token 获取记录摘要，无需登录
+func (c *ShareController) GetSharedRecord(ctx *gin.Context) {
+	record, err := c.service.GetSharedRecord(ctx.Param("token"))
+	if err != nil {
+		respondShareError(ctx, err)
+		return
+	}
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.JSON(http.StatusOK, record)
+}
+
+// RenderSharePage 渲染分享页 HTML，无需登录
+func (c *ShareController) RenderSharePage(ctx *gin.Context) {
+	// 分享页只给持有链接的人看，不希望被搜索引擎收录
+	ctx.Header("X-Robots-Tag", "noindex")
+	ctx.Header("Cache-Control", "no-cache")
+
+	record, err := c.service.GetSharedRecord(ctx.Param("token"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.String(http.StatusNotFound, "分享链接不存在或已失效")
+		} else {
+			ctx.String(http.StatusInternalServerError, "服务器错误")
+		}
+		return
+	}
+
+	headline := record.Exercise
+	if headline == "" {
+		headline = record.SportType
+	}
+	data := gin.H{
+		"Record":      record,
+		"Headline":    headline,
+		"Title":       fmt.Sprintf("%s的%s", record.User.Username, headline),
+		"Description": shareDescription(record),
+	}
+
+	var page bytes.Buffer
+	if err := sharePageTemplate.Execute(&page, data); err != nil {
+		ctx.String(http.StatusInternalServerError, "服务器错误")
+		return
+	}
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+// GetRecordCard 获取本人运动记录的分享卡片图片
+func (c *ShareController) GetRecordCard(ctx *gin.Context) {
+	recordID, ok := shareRecordID(ctx)
+	if !ok {
+		return
+	}
+
+	card, etag, err := c.service.RecordCard(ctx.GetInt64("user_id"), recordID, ctx.Query("theme"))
+	if err != nil {
+		respondShareError(ctx, err)
+		return
+	}
+	respondCard(ctx, card, etag)
+}
+
+// GetSharedRecordCard 通过分享 token 获取分享卡片图片，无需登录
+func (c *ShareController) GetSharedRecordCard(ctx *gin.Context) {
+	card, etag, err := c.service.SharedRecordCard(ctx.Param("token"), ctx.Query("theme"))
+	if err != nil {
+		respondShareError(ctx, err)
+		return
+	}
+	respondCard(ctx, card, etag)
+}
+
+// respondCard 返回卡片图片，客户端缓存的版本未变化时返回 304
+// 使用 no-cache 让客户端每次校验，链接撤销后不会继续展示旧图片
+func respondCard(ctx *gin.Context, card []byte, etag string) {
+	ctx.Header("ETag", etag)
+	ctx.Header("Cache-Control", "no-cache")
+	if ctx.GetHeader("If-None-Match") == etag {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.Data(http.StatusOK, "image/png", card)
+}
+
+// shareDescription 生成分享预览卡片的描述，例如“5.20 公里 · 32 分钟 · 300 千卡”
+func shareDescription(record *models.SharedRecord) string {
+	parts := make([]string, 0, 3)
+	if record.Distance > 0 {
+		parts = append(parts, fmt.Sprintf("%.2f 公里", record.Distance))
+	}
+	parts = append(parts, fmt.Sprintf("%d 分钟", record.Duration))
+	if record.Calories > 0 {
+		parts = append(parts, fmt.Sprintf("%d 千卡", record.Calories))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// respondShareError 根据错误类型返回对应的状态码
+func respondShareError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidShare):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "记录或分享链接不存在"})
+	case errors.Is(err, services.ErrShareCardUnavailable):
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": "分享卡片暂不可用，服务端未配置中文字体"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sports-app/backend/services"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 推送连接参数
+const (
+	streamHeartbeatInterval = 25 * time.Second
+	streamRetryMillis       = 3000
+)
+
+// StreamController 实时推送控制器
+type StreamController struct {
+	hub      *services.Hub
+	tickets  *services.StreamTicketStore
+	sessions *services.SessionService
+}
+
+// NewStreamController 创建实时推送控制器实例
+func NewStreamController(hub *services.Hub, tickets *services.StreamTicketStore, sessions *services.SessionService) *StreamController {
+	return &StreamController{hub: hub, tickets: tickets, sessions: sessions}
+}
+
+// IssueTicket 签发建立推送连接用的票据，票据绑定当前登录会话
+func (c *StreamController) IssueTicket(ctx *gin.Context) {
+	ticket, expiresAt, err := c.tickets.Issue(ctx.GetInt64("user_id"), ctx.GetUint64("session_id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "生成票据失败"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"ticket":     ticket,
+		"expires_at": expiresAt,
+	})
+}
+
+// Stream 建立 SSE 连接，推送通知、点赞和评论事件
+// 断线重连时通过 Last-Event-ID 请求头或 last_event_id 查询参数补发错过的事件
+// 本实例吊销会话时立即断开连接，其他实例吊销的会话在心跳时检查并断开
+func (c *StreamController) Stream(ctx *gin.Context) {
+	lastEventID := ctx.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = ctx.Query("last_event_id")
+	}
+	lastID, _ := strconv.ParseUint(lastEventID, 10, 64)
+
+	userID, sessionID := ctx.GetInt64("user_id"), ctx.GetUint64("session_id")
+	sub, resume := c.hub.Subscribe(userID, sessionID, lastID)
+	defer sub.Close()
+
+	header := ctx.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	w := ctx.Writer
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetryMillis)
+	if resume.Reset {
+		writeStreamEvent(w, resume.LastID, "reset", gin.H{"message": "部分事件已过期，请重新拉取数据"})
+	}
+	for _, event := range resume.Events {
+		writeStreamEvent(w, event.ID, event.Type, event.Data)
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case event, ok := <-sub.Events:
+			if !ok {
+				// 连接被推送中心断开（缓冲已满或会话已吊销），客户端会自动重连并补发，会话已吊销时重连会被拒绝
+				return
+			}
+			writeStreamEvent(w, event.ID, event.Type, event.Data)
+			w.Flush()
+		case now := <-heartbeat.C:
+			active, err := c.sessions.IsActive(userID, sessionID)
+			if err != nil {
+				log.Printf("查询会话状态失败: %v", err)
+			} else if !active {
+				return
+			}
+			writeStreamEvent(w, 0, "ping", gin.H{"time": now.Unix()})
+			w.Flush()
+		}
+	}
+}
+
+// writeStreamEvent 按 SSE 格式写入事件，id 为0时不更新客户端的 Last-Event-ID
+func writeStreamEvent(w io.Writer, id uint64, eventType string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		payload = []byte("{}")
+	}
+	if id > 0 {
+		fmt.Fprintf(w, "id: %d\n", id)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, payload)
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sports-app/backend/models"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// StrengthController 力量训练控制器
+type StrengthController struct {
+	service *services.StrengthService
+}
+
+// NewStrengthController 创建力量训练控制器实例
+func NewStrengthController(service *services.StrengthService) *StrengthController {
+	return &StrengthController{service: service}
+}
+
+// GetExercises 获取动作库
+func (c *StrengthController) GetExercises(ctx *gin.Context) {
+	userID := ctx.GetInt64("user_id")
+	exercises, err := c.service.GetExercises(userID, ctx.Query("category"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, exercises)
+}
+
+// CreateExercise 创建自定义动作
+func (c *StrengthController) CreateExercise(ctx *gin.Context) {
+	var exercise models.StrengthExercise
+	if err := ctx.ShouldBindJSON(&exercise); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.service.CreateExercise(ctx.GetInt64("user_id"), &exercise); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, exercise)
+}
+
+// DeleteExercise 删除自定义动作
+func (c *StrengthController) DeleteExercise(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid exercise ID"})
+		return
+	}
+
+	if err := c.service.DeleteExercise(ctx.GetInt64("user_id"), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "动作不存在或不可删除"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetProgression 获取动作的进步曲线
+func (c *StrengthController) GetProgression(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid exercise ID"})
+		return
+	}
+
+	timeRange := ctx.DefaultQuery("time_range", "year")
+	points, err := c.service.GetProgression(ctx.GetInt64("user_id"), id, timeRange)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, points)
+}
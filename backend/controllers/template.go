@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sports-app/backend/models"
+	"sports-app/backend/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// TemplateController 训练模板控制器
+type TemplateController struct {
+	service *services.TemplateService
+}
+
+// NewTemplateController 创建训练模板控制器实例
+func NewTemplateController(service *services.TemplateService) *TemplateController {
+	return &TemplateController{service: service}
+}
+
+// GetTemplates 获取训练模板列表（按使用频率排序）
+func (c *TemplateController) GetTemplates(ctx *gin.Context) {
+	templates, err := c.service.GetTemplates(ctx.GetInt64("user_id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, templates)
+}
+
+// CreateTemplate 创建训练模板
+func (c *TemplateController) CreateTemplate(ctx *gin.Context) {
+	var template models.WorkoutTemplate
+	if err := ctx.ShouldBindJSON(&template); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template.UserID = ctx.GetInt64("user_id")
+	if err := c.service.CreateTemplate(&template); err != nil {
+		respondTemplateError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, template)
+}
+
+// CreateTemplateFromRecord 将运动记录保存为训练模板
+func (c *TemplateController) CreateTemplateFromRecord(ctx *gin.Context) {
+	recordID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid record ID"})
+		return
+	}
+
+	var req struct {
+		Name string `json:"name" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := c.service.CreateTemplateFromRecord(ctx.GetInt64("user_id"), recordID, req.Name)
+	if err != nil {
+		respondTemplateError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, template)
+}
+
+// UpdateTemplate 更新训练模板
+func (c *TemplateController) UpdateTemplate(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	var template models.WorkoutTemplate
+	if err := ctx.ShouldBindJSON(&template); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template.ID = id
+	template.UserID = ctx.GetInt64("user_id")
+	if err := c.service.UpdateTemplate(&template); err != nil {
+		respondTemplateError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate 删除训练模板
+func (c *TemplateController) DeleteTemplate(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	if err := c.service.DeleteTemplate(ctx.GetInt64("user_id"), id); err != nil {
+		respondTemplateError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// CreateRecordFromTemplate 使用训练模板创建运动记录
+func (c *TemplateController) CreateRecordFromTemplate(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	// 覆盖字段均为可选，允许空请求体
+	var overrides services.TemplateOverrides
+	if err := ctx.ShouldBindJSON(&overrides); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	record, err := c.service.CreateRecordFromTemplate(ctx.GetInt64("user_id"), id, overrides)
+	if err != nil {
+		respondTemplateError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, record)
+}
+
+// respondTemplateError 根据错误类型返回对应的状态码
+func respondTemplateError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidTemplate), errors.Is(err, services.ErrInvalidRecord):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "模板或记录不存在"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -12,13 +12,15 @@ import (
 
 // UserController 用户控制器
 type UserController struct {
-	db *gorm.DB
+	db      *gorm.DB
+	follows *services.FollowService
 }
 
 // NewUserController 创建用户控制器
 func NewUserController(db *gorm.DB) *UserController {
 	return &UserController{
-		db: db,
+		db:      db,
+		follows: services.NewFollowService(db),
 	}
 }
 
@@ -61,15 +63,18 @@ func (uc *UserController) Login(c *gin.Context) {
 		return
 	}
 
-	token, err := services.GenerateToken(user.ID)
+	pair, err := services.NewSessionService(uc.db).CreateSession(user.ID, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "生成token失败"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "登录成功",
-		"token":   token,
+		"message":            "登录成功",
+		"token":              pair.Token,
+		"expires_at":         pair.ExpiresAt,
+		"refresh_token":      pair.RefreshToken,
+		"refresh_expires_at": pair.RefreshExpiresAt,
 		"user": gin.H{
 			"id":       user.ID,
 			"username": user.Username,
@@ -102,10 +107,18 @@ func (uc *UserController) GetProfile(c *gin.Context) {
 		return
 	}
 
+	counts, err := uc.follows.GetCounts(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "获取关注数据失败"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"id":       user.ID,
-		"username": user.Username,
-		"email":    user.Email,
+		"id":              user.ID,
+		"username":        user.Username,
+		"email":           user.Email,
+		"follower_count":  counts.FollowerCount,
+		"following_count": counts.FollowingCount,
 	})
 }
 
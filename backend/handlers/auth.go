@@ -54,15 +54,18 @@ func (c *AuthController) Login(ctx *gin.Context) {
         return
     }
 
-    token, err := services.GenerateToken(user.ID)
+    pair, err := services.NewSessionService(c.db).CreateSession(user.ID, ctx.Request.UserAgent(), ctx.ClientIP())
     if err != nil {
         ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
         return
     }
 
     ctx.JSON(http.StatusOK, gin.H{
-        "token": token,
-        "user":  user,
+        "token":              pair.Token,
+        "expires_at":         pair.ExpiresAt,
+        "refresh_token":      pair.RefreshToken,
+        "refresh_expires_at": pair.RefreshExpiresAt,
+        "user":               user,
     })
 }
 
@@ -5,6 +5,9 @@ import (
 	"log"
 	"sports-app/backend/config"
 	"sports-app/backend/routes"
+	"sports-app/backend/services"
+	"sports-app/backend/token"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -19,10 +22,26 @@ func main() {
 	// 2. 初始化配置（包括从环境变量读取 OSS 配置）
 	config.GetConfig()
 
+	// 加载访问令牌的签名密钥，密钥无效时无法签发和校验令牌
+	if _, err := token.Default(); err != nil {
+		log.Fatal("加载签名密钥失败:", err)
+	}
+	// 预加载分享卡片的中文字体；缺少字体时仅分享卡片接口不可用，其余接口照常提供服务
+	if _, err := services.GetShareCardRenderer(); err != nil {
+		log.Printf("警告: 分享卡片不可用: %v", err)
+	}
+
 	// 3. 初始化数据库连接
 	db := config.GetDB()
 	logsDB := config.GetLogsDB()
 
+	// 后台定期清理过期通知
+	go services.NewNotificationService(db).StartRetention(6 * time.Hour)
+	// 后台定期结算已结束的挑战
+	go services.NewChallengeService(db).StartFinalizer(time.Minute)
+	// 后台定期清理过期的登录会话和刷新令牌
+	go services.NewSessionService(db).StartRetention(6 * time.Hour)
+
 	// 4. 设置 Gin 路由
 	r := gin.Default()
 
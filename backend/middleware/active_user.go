@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"sports-app/backend/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ActiveUserRequired 禁止封禁中的用户发布内容和互动，读取类请求不受影响，需放在 AuthMiddleware 之后
+func ActiveUserRequired(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet {
+			c.Next()
+			return
+		}
+
+		var user models.User
+		if err := db.Select("id", "suspended_until").First(&user, c.GetInt64("user_id")).Error; err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "用户信息无效"})
+			return
+		}
+		if user.IsSuspended() {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":           "账号已被禁止发布内容",
+				"code":            "ACCOUNT_SUSPENDED",
+				"suspended_until": user.SuspendedUntil,
+			})
+			return
+		}
+		c.Next()
+	}
+}
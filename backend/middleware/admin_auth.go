@@ -2,15 +2,16 @@ package middleware
 
 import (
 	"net/http"
+	"sports-app/backend/models"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-// AdminAuth 管理员认证中间件
-func AdminAuth() gin.HandlerFunc {
+// AdminAuth 管理员认证中间件，需放在 AuthMiddleware 之后，按数据库中的角色判断权限
+func AdminAuth(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 从上下文中获取用户信息
-		user, exists := c.Get("user")
+		userID, exists := c.Get("user_id")
 		if !exists {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "未登录",
@@ -19,9 +20,8 @@ func AdminAuth() gin.HandlerFunc {
 			return
 		}
 
-		// 检查用户是否是管理员
-		userMap, ok := user.(map[string]interface{})
-		if !ok {
+		var user models.User
+		if err := db.Select("id", "role").First(&user, userID).Error; err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "用户信息无效",
 			})
@@ -29,8 +29,7 @@ func AdminAuth() gin.HandlerFunc {
 			return
 		}
 
-		isAdmin, ok := userMap["is_admin"].(bool)
-		if !ok || !isAdmin {
+		if user.Role != "admin" {
 			c.JSON(http.StatusForbidden, gin.H{
 				"error": "需要管理员权限",
 			})
@@ -40,4 +39,4 @@ func AdminAuth() gin.HandlerFunc {
 
 		c.Next()
 	}
-} 
\ No newline at end of file
+}
@@ -4,13 +4,16 @@ import (
 	"log"
 	"net/http"
 	"sports-app/backend/services"
+	"sports-app/backend/token"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-// AuthMiddleware 认证中间件
-func AuthMiddleware() gin.HandlerFunc {
+// AuthMiddleware 认证中间件，令牌所属的会话被吊销后立即拒绝，会话状态优先从内存缓存读取
+func AuthMiddleware(db *gorm.DB) gin.HandlerFunc {
+	sessions := services.NewSessionService(db)
 	return func(ctx *gin.Context) {
 		log.Printf("处理请求: %s %s", ctx.Request.Method, ctx.Request.URL.Path)
 		
@@ -37,7 +40,15 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		// 解析token
-		claims, err := services.ValidateToken(parts[1])
+		manager, err := token.Default()
+		if err != nil {
+			log.Printf("加载签名密钥失败: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error": "认证失败，请稍后重试",
+			})
+			return
+		}
+		claims, err := manager.Parse(parts[1])
 		if err != nil {
 			log.Printf("token验证失败: %v", err)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
@@ -48,9 +59,27 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		active, err := sessions.IsActive(claims.UserID, claims.SessionID)
+		if err != nil {
+			log.Printf("查询会话状态失败: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error": "认证失败，请稍后重试",
+			})
+			return
+		}
+		if !active {
+			log.Printf("用户 %d 的会话 %d 已失效", claims.UserID, claims.SessionID)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "登录已失效，请重新登录",
+				"code": "SESSION_REVOKED",
+			})
+			return
+		}
+
 		log.Printf("用户 %d 认证成功", claims.UserID)
-		// 将用户ID存储到上下文中
+		// 将用户ID和会话ID存储到上下文中
 		ctx.Set("user_id", claims.UserID)
+		ctx.Set("session_id", claims.SessionID)
 		ctx.Next()
 	}
 }
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"sports-app/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// StreamAuthMiddleware 推送连接认证中间件
+// 优先使用查询参数中的票据，未提供时按 Bearer token 认证；票据所属的会话被吊销后拒绝连接
+func StreamAuthMiddleware(db *gorm.DB) gin.HandlerFunc {
+	auth := AuthMiddleware(db)
+	sessions := services.NewSessionService(db)
+	tickets := services.GetStreamTicketStore()
+	return func(ctx *gin.Context) {
+		ticket := ctx.Query("ticket")
+		if ticket == "" {
+			auth(ctx)
+			return
+		}
+
+		userID, sessionID, ok := tickets.Redeem(ticket)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "无效或已过期的票据",
+				"code":  "INVALID_TICKET",
+			})
+			return
+		}
+		defer tickets.Release(ticket)
+
+		active, err := sessions.IsActive(userID, sessionID)
+		if err != nil {
+			log.Printf("查询会话状态失败: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error": "认证失败，请稍后重试",
+			})
+			return
+		}
+		if !active {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "登录已失效，请重新登录",
+				"code":  "SESSION_REVOKED",
+			})
+			return
+		}
+
+		ctx.Set("user_id", userID)
+		ctx.Set("session_id", sessionID)
+		ctx.Next()
+	}
+}
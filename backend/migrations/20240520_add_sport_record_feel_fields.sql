@@ -0,0 +1,10 @@
+-- 运动记录增加主观感受、备注、运动环境及心率字段
+ALTER TABLE `sport_records`
+  ADD COLUMN `rpe` tinyint DEFAULT NULL COMMENT '主观疲劳度（1-10）',
+  ADD COLUMN `mood` varchar(20) NOT NULL DEFAULT '' COMMENT '运动后心情：great/good/neutral/tired/bad',
+  ADD COLUMN `notes` text COMMENT '备注',
+  ADD COLUMN `environment` varchar(10) NOT NULL DEFAULT '' COMMENT '运动环境：indoor/outdoor',
+  ADD COLUMN `avg_heart_rate` smallint DEFAULT NULL COMMENT '平均心率（次/分）',
+  ADD COLUMN `max_heart_rate` smallint DEFAULT NULL COMMENT '最大心率（次/分）',
+  ADD INDEX `idx_sport_records_environment` (`environment`),
+  ADD INDEX `idx_sport_records_mood` (`mood`);
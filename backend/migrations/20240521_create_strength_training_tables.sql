@@ -0,0 +1,50 @@
+-- 力量训练动作库
+CREATE TABLE IF NOT EXISTS `strength_exercises` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) DEFAULT NULL COMMENT '为空表示系统内置动作',
+  `name` varchar(50) NOT NULL COMMENT '动作名称',
+  `category` varchar(30) DEFAULT NULL COMMENT '目标肌群',
+  `equipment` varchar(30) DEFAULT NULL COMMENT '器械',
+  `description` text COMMENT '动作说明',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_strength_exercises_user_id` (`user_id`),
+  KEY `idx_strength_exercises_category` (`category`),
+  KEY `idx_strength_exercises_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 运动记录中的力量训练分组
+CREATE TABLE IF NOT EXISTS `record_sets` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `record_id` bigint(20) NOT NULL COMMENT '运动记录ID',
+  `strength_exercise_id` bigint(20) NOT NULL COMMENT '动作ID',
+  `set_index` int NOT NULL COMMENT '组序号',
+  `reps` int NOT NULL COMMENT '次数',
+  `weight` decimal(7,2) NOT NULL DEFAULT 0 COMMENT '重量（千克）',
+  `rest_seconds` int NOT NULL DEFAULT 0 COMMENT '组间休息（秒）',
+  `rpe` tinyint DEFAULT NULL COMMENT '主观疲劳度（1-10）',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_record_sets_record_id` (`record_id`),
+  KEY `idx_record_sets_exercise_record` (`strength_exercise_id`, `record_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 插入内置动作
+INSERT INTO `strength_exercises` (`name`, `category`, `equipment`, `description`) VALUES
+('卧推', 'chest', 'barbell', '杠铃平板卧推'),
+('上斜卧推', 'chest', 'barbell', '杠铃上斜卧推'),
+('哑铃飞鸟', 'chest', 'dumbbell', '平板哑铃飞鸟'),
+('深蹲', 'legs', 'barbell', '杠铃颈后深蹲'),
+('前蹲', 'legs', 'barbell', '杠铃前蹲'),
+('腿举', 'legs', 'machine', '倒蹬腿举'),
+('硬拉', 'back', 'barbell', '杠铃传统硬拉'),
+('引体向上', 'back', 'bodyweight', '正手引体向上'),
+('杠铃划船', 'back', 'barbell', '俯身杠铃划船'),
+('高位下拉', 'back', 'machine', '宽握高位下拉'),
+('推举', 'shoulders', 'barbell', '站姿杠铃推举'),
+('哑铃侧平举', 'shoulders', 'dumbbell', '站姿哑铃侧平举'),
+('杠铃弯举', 'arms', 'barbell', '站姿杠铃弯举'),
+('双杠臂屈伸', 'arms', 'bodyweight', '双杠臂屈伸');
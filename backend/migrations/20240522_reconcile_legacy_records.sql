@@ -0,0 +1,16 @@
+-- 运动记录增加距离与可见范围，用于承接 exercises/check_ins 旧数据
+ALTER TABLE `sport_records`
+  ADD COLUMN `distance` decimal(10,2) NOT NULL DEFAULT 0 COMMENT '运动距离（公里）',
+  ADD COLUMN `visibility` varchar(20) NOT NULL DEFAULT 'private' COMMENT '可见范围：public/private';
+
+-- 旧表记录与运动记录的对应关系
+CREATE TABLE IF NOT EXISTS `legacy_record_mappings` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `source_table` varchar(30) NOT NULL COMMENT '来源表：exercises/check_ins',
+  `source_id` bigint(20) NOT NULL COMMENT '来源表记录ID',
+  `record_id` bigint(20) NOT NULL COMMENT '运动记录ID',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_legacy_source` (`source_table`, `source_id`),
+  KEY `idx_legacy_record_mappings_record_id` (`record_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,19 @@
+-- 训练模板表
+CREATE TABLE IF NOT EXISTS `workout_templates` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) NOT NULL COMMENT '用户ID',
+  `name` varchar(50) NOT NULL COMMENT '模板名称',
+  `sport_type_id` bigint(20) NOT NULL COMMENT '运动类型ID',
+  `exercise` varchar(255) DEFAULT NULL COMMENT '运动内容',
+  `duration` bigint NOT NULL DEFAULT 0 COMMENT '常规时长（分钟）',
+  `calories` bigint NOT NULL DEFAULT 0 COMMENT '消耗卡路里',
+  `notes` text COMMENT '备注',
+  `usage_count` bigint NOT NULL DEFAULT 0 COMMENT '使用次数',
+  `last_used_at` timestamp NULL DEFAULT NULL COMMENT '最近使用时间',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_workout_templates_user_usage` (`user_id`, `usage_count`),
+  KEY `idx_workout_templates_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
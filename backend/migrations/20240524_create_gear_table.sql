@@ -0,0 +1,23 @@
+-- 运动装备表
+CREATE TABLE IF NOT EXISTS `gear` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) NOT NULL COMMENT '用户ID',
+  `name` varchar(50) NOT NULL COMMENT '装备名称',
+  `type` varchar(20) NOT NULL COMMENT '装备类型：shoes/bike/racket/other',
+  `brand` varchar(50) DEFAULT NULL COMMENT '品牌',
+  `purchase_date` datetime DEFAULT NULL COMMENT '购买日期',
+  `retirement_distance` decimal(10,2) NOT NULL DEFAULT 0 COMMENT '建议退役里程（公里）',
+  `retirement_duration` bigint NOT NULL DEFAULT 0 COMMENT '建议退役时长（分钟）',
+  `retired_at` datetime DEFAULT NULL COMMENT '退役时间',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_gear_user_id` (`user_id`),
+  KEY `idx_gear_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 运动记录关联装备
+ALTER TABLE `sport_records`
+  ADD COLUMN `gear_id` bigint(20) DEFAULT NULL COMMENT '使用的装备ID',
+  ADD INDEX `idx_sport_records_gear_id` (`gear_id`);
@@ -0,0 +1,47 @@
+-- 社区打卡表
+CREATE TABLE IF NOT EXISTS `check_ins` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL COMMENT '用户ID',
+  `sport_type_id` bigint unsigned NOT NULL COMMENT '运动类型ID',
+  `duration` int NOT NULL COMMENT '运动时长（分钟）',
+  `images` text COMMENT '图片URL，多个用逗号分隔',
+  `description` text COMMENT '描述',
+  `is_shared` tinyint(1) DEFAULT 0 COMMENT '是否公开',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_check_ins_user_id` (`user_id`),
+  KEY `idx_check_ins_shared_created` (`is_shared`, `created_at`),
+  KEY `idx_check_ins_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 评论表
+CREATE TABLE IF NOT EXISTS `comments` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL COMMENT '用户ID',
+  `check_in_id` bigint unsigned NOT NULL COMMENT '打卡ID',
+  `content` text NOT NULL COMMENT '评论内容',
+  `parent_id` bigint unsigned DEFAULT NULL COMMENT '父评论ID',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_comments_check_in_id` (`check_in_id`),
+  KEY `idx_comments_parent_id` (`parent_id`),
+  KEY `idx_comments_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 点赞表
+CREATE TABLE IF NOT EXISTS `likes` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL COMMENT '用户ID',
+  `check_in_id` bigint unsigned NOT NULL COMMENT '打卡ID',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_user_check_in` (`user_id`, `check_in_id`),
+  KEY `idx_likes_check_in_id` (`check_in_id`),
+  KEY `idx_likes_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,7 @@
+-- 评论增加楼层归属、删除占位及编辑时间
+ALTER TABLE `comments`
+  ADD COLUMN `root_id` bigint unsigned DEFAULT NULL COMMENT '所属顶层评论ID',
+  ADD COLUMN `is_deleted` tinyint(1) NOT NULL DEFAULT 0 COMMENT '已删除但保留占位',
+  ADD COLUMN `edited_at` timestamp NULL DEFAULT NULL COMMENT '编辑时间',
+  ADD INDEX `idx_comments_root_id` (`root_id`),
+  ADD INDEX `idx_comments_check_in_parent` (`check_in_id`, `parent_id`, `created_at`);
@@ -0,0 +1,8 @@
+-- 打卡增加冗余点赞数，点赞改为物理删除
+DELETE FROM `likes` WHERE `deleted_at` IS NOT NULL;
+
+ALTER TABLE `check_ins`
+  ADD COLUMN `like_count` bigint NOT NULL DEFAULT 0 COMMENT '点赞数';
+
+UPDATE `check_ins` c
+SET c.`like_count` = (SELECT COUNT(*) FROM `likes` l WHERE l.`check_in_id` = c.`id`);
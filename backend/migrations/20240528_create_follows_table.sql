@@ -0,0 +1,14 @@
+-- 用户关注关系
+CREATE TABLE IF NOT EXISTS `follows` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `follower_id` bigint NOT NULL COMMENT '关注者ID',
+  `followee_id` bigint NOT NULL COMMENT '被关注者ID',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_follower_followee` (`follower_id`, `followee_id`),
+  KEY `idx_follows_followee_id` (`followee_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户关注关系';
+
+-- 关注动态按用户逐个取最新打卡
+ALTER TABLE `check_ins`
+  ADD INDEX `idx_check_ins_user_created` (`user_id`, `created_at`);
@@ -0,0 +1,28 @@
+-- 站内通知
+CREATE TABLE IF NOT EXISTS `notifications` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint NOT NULL COMMENT '接收者ID',
+  `type` varchar(20) NOT NULL COMMENT '通知类型：like/comment/reply/follow/badge',
+  `target_type` varchar(20) NOT NULL COMMENT '关联对象类型',
+  `target_id` bigint unsigned NOT NULL COMMENT '关联对象ID',
+  `group_key` varchar(100) NOT NULL COMMENT '合并键',
+  `actor_id` bigint DEFAULT NULL COMMENT '最近一次触发的用户ID',
+  `actor_count` int NOT NULL DEFAULT 1 COMMENT '合并的不同用户数',
+  `content` varchar(255) DEFAULT NULL COMMENT '评论摘要、勋章名称等',
+  `is_read` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否已读',
+  `read_at` timestamp NULL DEFAULT NULL COMMENT '阅读时间',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最近一次合并时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_notifications_user_read` (`user_id`, `is_read`),
+  KEY `idx_notifications_group_key` (`group_key`),
+  KEY `idx_notifications_updated_at` (`updated_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='站内通知';
+
+-- 参与合并通知的用户，用于去重计数
+CREATE TABLE IF NOT EXISTS `notification_actors` (
+  `notification_id` bigint unsigned NOT NULL COMMENT '通知ID',
+  `actor_id` bigint NOT NULL COMMENT '用户ID',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`notification_id`, `actor_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='通知参与用户';
@@ -0,0 +1,25 @@
+-- 打卡和评论增加隐藏标记，命中敏感词待审核或被管理员隐藏时仅作者可见
+ALTER TABLE `check_ins`
+  ADD COLUMN `is_hidden` tinyint(1) NOT NULL DEFAULT 0 COMMENT '待审核或已隐藏';
+
+ALTER TABLE `comments`
+  ADD COLUMN `is_hidden` tinyint(1) NOT NULL DEFAULT 0 COMMENT '待审核或已隐藏';
+
+-- 内容审核队列
+CREATE TABLE IF NOT EXISTS `moderation_reviews` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `target_type` varchar(20) NOT NULL COMMENT '对象类型：check_in/comment/user',
+  `target_id` bigint unsigned NOT NULL COMMENT '对象ID',
+  `user_id` bigint NOT NULL COMMENT '内容作者ID',
+  `content` text COMMENT '提交审核时的原文',
+  `matched_words` varchar(255) DEFAULT NULL COMMENT '命中的敏感词',
+  `status` varchar(20) NOT NULL DEFAULT 'pending' COMMENT '状态：pending/approved/rejected',
+  `reviewer_id` bigint DEFAULT NULL COMMENT '审核人ID',
+  `reviewed_at` timestamp NULL DEFAULT NULL COMMENT '审核时间',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_reviews_target` (`target_type`, `target_id`),
+  KEY `idx_moderation_reviews_user_id` (`user_id`),
+  KEY `idx_moderation_reviews_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='内容审核队列';
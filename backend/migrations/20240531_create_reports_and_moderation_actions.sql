@@ -0,0 +1,43 @@
+-- 用户封禁
+ALTER TABLE `users`
+  ADD COLUMN `suspended_until` timestamp NULL DEFAULT NULL COMMENT '封禁到期时间';
+
+-- 用户举报
+CREATE TABLE IF NOT EXISTS `reports` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `reporter_id` bigint NOT NULL COMMENT '举报人ID',
+  `target_type` varchar(20) NOT NULL COMMENT '对象类型：check_in/comment/user',
+  `target_id` bigint unsigned NOT NULL COMMENT '对象ID',
+  `target_user_id` bigint NOT NULL COMMENT '被举报内容的作者ID',
+  `reason` varchar(20) NOT NULL COMMENT '举报原因：spam/abuse/sexual/illegal/other',
+  `details` varchar(500) DEFAULT NULL COMMENT '举报说明',
+  `status` varchar(20) NOT NULL DEFAULT 'pending' COMMENT '状态：pending/resolved/dismissed',
+  `action` varchar(20) DEFAULT NULL COMMENT '处理时采取的操作',
+  `resolved_by` bigint DEFAULT NULL COMMENT '处理人ID',
+  `resolved_at` timestamp NULL DEFAULT NULL COMMENT '处理时间',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_reporter_target` (`reporter_id`, `target_type`, `target_id`),
+  KEY `idx_reports_target` (`target_type`, `target_id`),
+  KEY `idx_reports_target_user_id` (`target_user_id`),
+  KEY `idx_reports_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户举报';
+
+-- 管理员审核操作记录
+CREATE TABLE IF NOT EXISTS `moderation_actions` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `moderator_id` bigint NOT NULL COMMENT '操作的管理员ID',
+  `action` varchar(20) NOT NULL COMMENT '操作：hide/restore/dismiss/warn/suspend/approve_review/reject_review',
+  `target_type` varchar(20) NOT NULL COMMENT '对象类型',
+  `target_id` bigint unsigned NOT NULL COMMENT '对象ID',
+  `target_user_id` bigint NOT NULL COMMENT '受影响的用户ID',
+  `report_id` bigint unsigned DEFAULT NULL COMMENT '关联的举报ID',
+  `review_id` bigint unsigned DEFAULT NULL COMMENT '关联的敏感词审核ID',
+  `note` varchar(500) DEFAULT NULL COMMENT '备注',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_moderation_actions_moderator_id` (`moderator_id`),
+  KEY `idx_moderation_actions_target` (`target_type`, `target_id`),
+  KEY `idx_moderation_actions_target_user_id` (`target_user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='管理员审核操作记录';
@@ -0,0 +1,42 @@
+-- 私信权限
+ALTER TABLE `users`
+  ADD COLUMN `message_privacy` varchar(20) NOT NULL DEFAULT 'everyone' COMMENT '私信权限：everyone/following/nobody';
+
+-- 私信会话，每对用户一个会话
+CREATE TABLE IF NOT EXISTS `conversations` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_low_id` bigint NOT NULL COMMENT '较小的用户ID',
+  `user_high_id` bigint NOT NULL COMMENT '较大的用户ID',
+  `last_message_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '最新私信ID',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_conversation_users` (`user_low_id`, `user_high_id`),
+  KEY `idx_conversations_user_high_id` (`user_high_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='私信会话';
+
+-- 会话参与者的未读数与已读位置
+CREATE TABLE IF NOT EXISTS `conversation_members` (
+  `conversation_id` bigint unsigned NOT NULL COMMENT '会话ID',
+  `user_id` bigint NOT NULL COMMENT '用户ID',
+  `peer_id` bigint NOT NULL COMMENT '会话对方ID',
+  `unread_count` bigint NOT NULL DEFAULT 0 COMMENT '未读私信数',
+  `last_read_message_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '已读到的私信ID',
+  `last_message_at` timestamp NULL DEFAULT NULL COMMENT '最新私信时间',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`conversation_id`, `user_id`),
+  KEY `idx_conversation_members_user_last` (`user_id`, `last_message_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='私信会话参与者';
+
+-- 私信
+CREATE TABLE IF NOT EXISTS `messages` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `conversation_id` bigint unsigned NOT NULL COMMENT '会话ID',
+  `sender_id` bigint NOT NULL COMMENT '发送者ID',
+  `recipient_id` bigint NOT NULL COMMENT '接收者ID',
+  `content` text NOT NULL COMMENT '私信内容',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_messages_conversation_id` (`conversation_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='私信';
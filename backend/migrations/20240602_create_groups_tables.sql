@@ -0,0 +1,43 @@
+-- 群组（groups 为 MySQL 8 保留字，表名使用 sport_groups）
+CREATE TABLE IF NOT EXISTS `sport_groups` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL COMMENT '群组名称',
+  `description` varchar(500) DEFAULT NULL COMMENT '群组简介',
+  `avatar_url` varchar(255) DEFAULT NULL COMMENT '群组头像',
+  `owner_id` bigint NOT NULL COMMENT '群主ID',
+  `join_mode` varchar(20) NOT NULL DEFAULT 'open' COMMENT '加入方式：open/approval/invite',
+  `invite_code` varchar(16) NOT NULL COMMENT '邀请码',
+  `member_count` bigint NOT NULL DEFAULT 0 COMMENT '成员数',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_sport_groups_invite_code` (`invite_code`),
+  KEY `idx_sport_groups_owner_id` (`owner_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='群组';
+
+-- 群组成员
+CREATE TABLE IF NOT EXISTS `group_members` (
+  `group_id` bigint unsigned NOT NULL COMMENT '群组ID',
+  `user_id` bigint NOT NULL COMMENT '用户ID',
+  `role` varchar(20) NOT NULL DEFAULT 'member' COMMENT '角色：owner/admin/member',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT '加入时间',
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`group_id`, `user_id`),
+  KEY `idx_group_members_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='群组成员';
+
+-- 入群申请
+CREATE TABLE IF NOT EXISTS `group_join_requests` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `group_id` bigint unsigned NOT NULL COMMENT '群组ID',
+  `user_id` bigint NOT NULL COMMENT '申请人ID',
+  `message` varchar(200) DEFAULT NULL COMMENT '申请留言',
+  `status` varchar(20) NOT NULL DEFAULT 'pending' COMMENT '状态：pending/approved/rejected',
+  `handled_by` bigint DEFAULT NULL COMMENT '审批人ID',
+  `handled_at` timestamp NULL DEFAULT NULL COMMENT '审批时间',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_group_request_user` (`group_id`, `user_id`),
+  KEY `idx_group_join_requests_status` (`group_id`, `status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='入群申请';
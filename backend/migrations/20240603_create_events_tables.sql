@@ -0,0 +1,40 @@
+-- 活动，group_id 为空时为公开活动
+CREATE TABLE IF NOT EXISTS `events` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `group_id` bigint unsigned DEFAULT NULL COMMENT '所属群组ID',
+  `organizer_id` bigint NOT NULL COMMENT '组织者ID',
+  `title` varchar(100) NOT NULL COMMENT '标题',
+  `description` varchar(2000) DEFAULT NULL COMMENT '描述',
+  `sport_type_id` bigint NOT NULL COMMENT '运动类型ID',
+  `location` varchar(200) DEFAULT NULL COMMENT '地点',
+  `start_time` datetime NOT NULL COMMENT '开始时间',
+  `end_time` datetime NOT NULL COMMENT '结束时间',
+  `capacity` int NOT NULL DEFAULT 0 COMMENT '参加人数上限，0 表示不限',
+  `status` varchar(20) NOT NULL DEFAULT 'scheduled' COMMENT '状态：scheduled/canceled',
+  `going_count` int NOT NULL DEFAULT 0 COMMENT '参加人数',
+  `maybe_count` int NOT NULL DEFAULT 0 COMMENT '可能参加人数',
+  `waitlist_count` int NOT NULL DEFAULT 0 COMMENT '候补人数',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_events_group_id` (`group_id`),
+  KEY `idx_events_organizer_id` (`organizer_id`),
+  KEY `idx_events_start_time` (`start_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='活动';
+
+-- 活动报名
+CREATE TABLE IF NOT EXISTS `event_rsvps` (
+  `event_id` bigint unsigned NOT NULL COMMENT '活动ID',
+  `user_id` bigint NOT NULL COMMENT '用户ID',
+  `status` varchar(20) NOT NULL COMMENT '状态：going/maybe/declined/waitlisted',
+  `waitlisted_at` timestamp NULL DEFAULT NULL COMMENT '进入候补时间',
+  `attended` tinyint(1) NOT NULL DEFAULT 0 COMMENT '组织者确认已到场',
+  `checked_in_at` timestamp NULL DEFAULT NULL COMMENT '签到确认时间',
+  `sport_record_id` bigint DEFAULT NULL COMMENT '关联的运动记录ID',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`event_id`, `user_id`),
+  UNIQUE KEY `idx_event_rsvps_sport_record_id` (`sport_record_id`),
+  KEY `idx_event_rsvps_user_id` (`user_id`),
+  KEY `idx_event_rsvps_waitlist` (`event_id`, `status`, `waitlisted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='活动报名';
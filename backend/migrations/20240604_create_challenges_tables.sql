@@ -0,0 +1,53 @@
+-- 挑战，进度按时间范围内符合运动类型的运动记录计算
+CREATE TABLE IF NOT EXISTS `challenges` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `creator_id` bigint NOT NULL COMMENT '创建者ID',
+  `title` varchar(100) NOT NULL COMMENT '标题',
+  `description` varchar(2000) DEFAULT NULL COMMENT '描述',
+  `type` varchar(20) NOT NULL DEFAULT 'individual' COMMENT '类型：individual/team',
+  `metric` varchar(20) NOT NULL COMMENT '统计指标：duration/distance/calories/count',
+  `goal` double NOT NULL DEFAULT 0 COMMENT '目标值，0 表示只比排名',
+  `sport_types` varchar(255) DEFAULT NULL COMMENT '允许的运动类型ID，逗号分隔，为空表示不限',
+  `start_time` datetime NOT NULL COMMENT '开始时间',
+  `end_time` datetime NOT NULL COMMENT '结束时间',
+  `participant_count` bigint NOT NULL DEFAULT 0 COMMENT '参与人数',
+  `finalized_at` timestamp NULL DEFAULT NULL COMMENT '结算时间，结算后排名冻结',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_challenges_creator_id` (`creator_id`),
+  KEY `idx_challenges_start_time` (`start_time`),
+  KEY `idx_challenges_end_time` (`end_time`),
+  KEY `idx_challenges_finalized_at` (`finalized_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='挑战';
+
+-- 挑战参与者
+CREATE TABLE IF NOT EXISTS `challenge_participants` (
+  `challenge_id` bigint unsigned NOT NULL COMMENT '挑战ID',
+  `user_id` bigint NOT NULL COMMENT '用户ID',
+  `team_id` bigint unsigned DEFAULT NULL COMMENT '团队挑战中所属的群组ID',
+  `progress` double NOT NULL DEFAULT 0 COMMENT '当前进度',
+  `record_count` bigint NOT NULL DEFAULT 0 COMMENT '计入进度的运动记录数',
+  `completed_at` timestamp NULL DEFAULT NULL COMMENT '达成目标的时间',
+  `final_rank` int DEFAULT NULL COMMENT '结算后的最终排名',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`challenge_id`, `user_id`),
+  KEY `idx_challenge_participants_user_id` (`user_id`),
+  KEY `idx_challenge_participants_team_id` (`team_id`),
+  KEY `idx_challenge_participants_progress` (`challenge_id`, `progress`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='挑战参与者';
+
+-- 团队挑战的队伍
+CREATE TABLE IF NOT EXISTS `challenge_teams` (
+  `challenge_id` bigint unsigned NOT NULL COMMENT '挑战ID',
+  `group_id` bigint unsigned NOT NULL COMMENT '群组ID',
+  `member_count` bigint NOT NULL DEFAULT 0 COMMENT '参与挑战的队员数',
+  `progress` double NOT NULL DEFAULT 0 COMMENT '队员进度之和',
+  `completed_at` timestamp NULL DEFAULT NULL COMMENT '达成目标的时间',
+  `final_rank` int DEFAULT NULL COMMENT '结算后的最终排名',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`challenge_id`, `group_id`),
+  KEY `idx_challenge_teams_progress` (`challenge_id`, `progress`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='团队挑战的队伍';
@@ -0,0 +1,19 @@
+-- 打卡的可见范围由 is_shared 改为 visibility：public/followers/private
+ALTER TABLE `check_ins`
+  ADD COLUMN `visibility` varchar(20) NOT NULL DEFAULT 'private' COMMENT '可见范围：public/followers/private' AFTER `description`;
+
+UPDATE `check_ins` SET `visibility` = 'public' WHERE `is_shared` = 1;
+
+ALTER TABLE `check_ins`
+  DROP INDEX `idx_check_ins_shared_created`,
+  DROP COLUMN `is_shared`,
+  ADD KEY `idx_check_ins_visibility_created` (`visibility`, `created_at`);
+
+ALTER TABLE `sport_records`
+  MODIFY COLUMN `visibility` varchar(20) NOT NULL DEFAULT 'private' COMMENT '可见范围：public/followers/private';
+
+-- 账号级隐私设置
+ALTER TABLE `users`
+  ADD COLUMN `default_record_visibility` varchar(20) NOT NULL DEFAULT 'private' COMMENT '新运动记录的默认可见范围',
+  ADD COLUMN `default_check_in_visibility` varchar(20) NOT NULL DEFAULT 'private' COMMENT '新打卡的默认可见范围',
+  ADD COLUMN `profile_visibility` varchar(20) NOT NULL DEFAULT 'public' COMMENT '关注数据等资料的可见范围';
@@ -0,0 +1,20 @@
+-- 用户屏蔽关系，双向生效
+CREATE TABLE IF NOT EXISTS `user_blocks` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `blocker_id` bigint NOT NULL COMMENT '屏蔽者ID',
+  `blocked_id` bigint NOT NULL COMMENT '被屏蔽者ID',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_blocker_blocked` (`blocker_id`, `blocked_id`),
+  KEY `idx_user_blocks_blocked_id` (`blocked_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户屏蔽关系';
+
+-- 用户静音关系，只对静音者的动态生效
+CREATE TABLE IF NOT EXISTS `user_mutes` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `muter_id` bigint NOT NULL COMMENT '静音者ID',
+  `muted_id` bigint NOT NULL COMMENT '被静音者ID',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_muter_muted` (`muter_id`, `muted_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户静音关系';
@@ -0,0 +1,15 @@
+-- 运动记录公开分享链接，凭 token 无需登录即可查看记录摘要
+CREATE TABLE IF NOT EXISTS `record_shares` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `record_id` bigint NOT NULL COMMENT '运动记录ID',
+  `user_id` bigint NOT NULL COMMENT '创建者ID',
+  `token` varchar(64) NOT NULL COMMENT '分享token，随机生成',
+  `expires_at` timestamp NULL DEFAULT NULL COMMENT '过期时间，为空表示长期有效',
+  `revoked_at` timestamp NULL DEFAULT NULL COMMENT '撤销时间',
+  `view_count` bigint NOT NULL DEFAULT 0 COMMENT '访问次数',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_record_shares_token` (`token`),
+  KEY `idx_record_shares_record_id` (`record_id`),
+  KEY `idx_record_shares_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='运动记录分享链接';
@@ -0,0 +1,28 @@
+-- 话题，名称为规范化后的小写、简体、半角形式
+CREATE TABLE IF NOT EXISTS `hashtags` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL COMMENT '话题名',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_hashtags_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='话题';
+
+-- 打卡描述中出现的话题，(hashtag_id, check_in_id) 用于话题动态，created_at 用于热门话题统计
+CREATE TABLE IF NOT EXISTS `check_in_hashtags` (
+  `check_in_id` bigint unsigned NOT NULL COMMENT '打卡ID',
+  `hashtag_id` bigint unsigned NOT NULL COMMENT '话题ID',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`check_in_id`, `hashtag_id`),
+  KEY `idx_check_in_hashtags_hashtag` (`hashtag_id`, `check_in_id`),
+  KEY `idx_check_in_hashtags_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='打卡话题';
+
+-- 评论内容中出现的话题
+CREATE TABLE IF NOT EXISTS `comment_hashtags` (
+  `comment_id` bigint unsigned NOT NULL COMMENT '评论ID',
+  `hashtag_id` bigint unsigned NOT NULL COMMENT '话题ID',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`comment_id`, `hashtag_id`),
+  KEY `idx_comment_hashtags_hashtag` (`hashtag_id`, `comment_id`),
+  KEY `idx_comment_hashtags_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='评论话题';
@@ -0,0 +1,12 @@
+-- 搜索使用的全文索引，ngram 分词支持中文，默认 ngram_token_size 为 2，单字关键词回退为 LIKE 查询
+ALTER TABLE `sport_records`
+  ADD FULLTEXT KEY `ft_sport_records_search` (`exercise`, `notes`) WITH PARSER ngram;
+
+ALTER TABLE `check_ins`
+  ADD FULLTEXT KEY `ft_check_ins_description` (`description`) WITH PARSER ngram;
+
+ALTER TABLE `users`
+  ADD FULLTEXT KEY `ft_users_username` (`username`) WITH PARSER ngram;
+
+ALTER TABLE `sport_types`
+  ADD FULLTEXT KEY `ft_sport_types_name` (`name`) WITH PARSER ngram;
@@ -0,0 +1,28 @@
+-- 登录会话，每次登录创建一个会话，会话内轮换产生的刷新令牌属于同一令牌族
+CREATE TABLE IF NOT EXISTS `auth_sessions` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint NOT NULL COMMENT '用户ID',
+  `user_agent` varchar(255) DEFAULT NULL COMMENT '登录设备',
+  `ip` varchar(45) DEFAULT NULL COMMENT '登录IP',
+  `last_used_at` timestamp NULL DEFAULT NULL COMMENT '最近一次刷新令牌的时间',
+  `expires_at` timestamp NOT NULL COMMENT '最新刷新令牌的过期时间',
+  `revoked_at` timestamp NULL DEFAULT NULL COMMENT '失效时间',
+  `revoke_reason` varchar(20) DEFAULT NULL COMMENT '失效原因：logout/logout_all/reuse/password_reset',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_auth_sessions_user_id` (`user_id`),
+  KEY `idx_auth_sessions_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='登录会话';
+
+-- 刷新令牌，只保存 SHA-256 摘要，已轮换的令牌保留到过期用于识别重复使用
+CREATE TABLE IF NOT EXISTS `refresh_tokens` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `session_id` bigint unsigned NOT NULL COMMENT '会话ID',
+  `token_hash` varchar(64) NOT NULL COMMENT '令牌摘要',
+  `expires_at` timestamp NOT NULL COMMENT '过期时间',
+  `used_at` timestamp NULL DEFAULT NULL COMMENT '轮换时间',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_refresh_tokens_token_hash` (`token_hash`),
+  KEY `idx_refresh_tokens_session_id` (`session_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='刷新令牌';
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// 会话失效原因
+const (
+	SessionRevokeLogout        = "logout"         // 用户登出
+	SessionRevokeLogoutAll     = "logout_all"     // 用户退出所有设备
+	SessionRevokeReuse         = "reuse"          // 已轮换的刷新令牌被再次使用，疑似泄露
+	SessionRevokePasswordReset = "password_reset" // 重置密码
+)
+
+// AuthSession 登录会话，每次登录创建一个会话，会话内轮换产生的刷新令牌属于同一令牌族
+type AuthSession struct {
+	ID           uint64     `gorm:"primaryKey" json:"id"`
+	UserID       int64      `gorm:"not null;index" json:"user_id"`
+	UserAgent    string     `gorm:"size:255" json:"user_agent"`
+	IP           string     `gorm:"size:45" json:"ip"`
+	LastUsedAt   time.Time  `json:"last_used_at"`                           // 最近一次刷新令牌的时间
+	ExpiresAt    time.Time  `gorm:"not null;index" json:"expires_at"`       // 最新刷新令牌的过期时间
+	RevokedAt    *time.Time `json:"revoked_at"`                             // 失效时间，失效后访问令牌和刷新令牌都不能再使用
+	RevokeReason string     `gorm:"size:20" json:"revoke_reason,omitempty"` // 失效原因
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// TableName 指定表名
+func (AuthSession) TableName() string {
+	return "auth_sessions"
+}
+
+// RefreshToken 刷新令牌，只保存 SHA-256 摘要
+// 每次刷新后旧令牌标记为已使用，已使用的令牌再次出现时视为泄露，整个会话失效
+type RefreshToken struct {
+	ID        uint64     `gorm:"primaryKey" json:"id"`
+	SessionID uint64     `gorm:"not null;index" json:"session_id"`
+	TokenHash string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"` // 轮换时间
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName 指定表名
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// TokenPair 登录或刷新后签发的令牌
+type TokenPair struct {
+	Token            string    `json:"token"`      // 访问令牌
+	ExpiresAt        time.Time `json:"expires_at"` // 访问令牌过期时间
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// UserBlock 屏蔽关系，屏蔽后双方互相看不到对方的内容，也不能互动、关注或私信
+type UserBlock struct {
+	ID        uint64      `gorm:"primaryKey" json:"id"`
+	BlockerID int64       `gorm:"not null;uniqueIndex:uk_blocker_blocked" json:"blocker_id"`       // 屏蔽者
+	BlockedID int64       `gorm:"not null;uniqueIndex:uk_blocker_blocked;index" json:"blocked_id"` // 被屏蔽者
+	Blocked   *PublicUser `gorm:"foreignKey:BlockedID" json:"blocked,omitempty"`                   // 被屏蔽者信息
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// TableName 指定表名
+func (UserBlock) TableName() string {
+	return "user_blocks"
+}
+
+// UserMute 静音关系，只对静音者生效：被静音用户的内容不出现在静音者的动态中
+type UserMute struct {
+	ID        uint64      `gorm:"primaryKey" json:"id"`
+	MuterID   int64       `gorm:"not null;uniqueIndex:uk_muter_muted" json:"muter_id"` // 静音者
+	MutedID   int64       `gorm:"not null;uniqueIndex:uk_muter_muted" json:"muted_id"` // 被静音者
+	Muted     *PublicUser `gorm:"foreignKey:MutedID" json:"muted,omitempty"`           // 被静音者信息
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// TableName 指定表名
+func (UserMute) TableName() string {
+	return "user_mutes"
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 挑战类型
+const (
+	ChallengeTypeIndividual = "individual" // 个人挑战
+	ChallengeTypeTeam       = "team"       // 团队挑战，以群组为队伍
+)
+
+// 挑战统计指标
+const (
+	ChallengeMetricDuration = "duration" // 运动时长（分钟）
+	ChallengeMetricDistance = "distance" // 距离（公里）
+	ChallengeMetricCalories = "calories" // 消耗卡路里
+	ChallengeMetricCount    = "count"    // 运动次数
+)
+
+// 挑战状态，根据时间和结算情况计算
+const (
+	ChallengeStatusUpcoming = "upcoming" // 未开始
+	ChallengeStatusActive   = "active"   // 进行中
+	ChallengeStatusClosed   = "closed"   // 已结束，排名已冻结
+)
+
+// Challenge 限时挑战，进度按挑战时间范围内符合运动类型的运动记录计算
+type Challenge struct {
+	ID               uint64                `gorm:"primaryKey" json:"id"`
+	CreatorID        int64                 `gorm:"not null;index" json:"creator_id"`
+	Creator          *PublicUser           `gorm:"foreignKey:CreatorID" json:"creator,omitempty"`
+	Title            string                `gorm:"size:100;not null" json:"title"`
+	Description      string                `gorm:"size:2000" json:"description"`
+	Type             string                `gorm:"size:20;not null;default:'individual'" json:"type"`
+	Metric           string                `gorm:"size:20;not null" json:"metric"`
+	Goal             float64               `gorm:"not null;default:0" json:"goal"` // 目标值，0 表示只比排名
+	SportTypes       string                `gorm:"size:255" json:"-"`              // 允许的运动类型ID，逗号分隔，为空表示不限
+	SportTypeIDs     []int64               `gorm:"-" json:"sport_type_ids"`        // 允许的运动类型ID
+	StartTime        time.Time             `gorm:"not null;index" json:"start_time"`
+	EndTime          time.Time             `gorm:"not null;index" json:"end_time"`
+	ParticipantCount int64                 `gorm:"not null;default:0" json:"participant_count"` // 参与人数，随加入/退出在事务中更新
+	FinalizedAt      *time.Time            `gorm:"index" json:"finalized_at"`                   // 结算时间，结算后排名冻结
+	Status           string                `gorm:"-" json:"status"`                             // upcoming/active/closed
+	Me               *ChallengeParticipant `gorm:"-" json:"me"`                                 // 当前用户的参与情况，未参与时为空
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (Challenge) TableName() string {
+	return "challenges"
+}
+
+// BeforeSave 保存前将运动类型列表转换为逗号分隔的字符串
+func (c *Challenge) BeforeSave(tx *gorm.DB) error {
+	ids := make([]string, len(c.SportTypeIDs))
+	for i, id := range c.SportTypeIDs {
+		ids[i] = strconv.FormatInt(id, 10)
+	}
+	c.SportTypes = strings.Join(ids, ",")
+	return nil
+}
+
+// AfterFind 查询后解析运动类型列表并计算状态
+func (c *Challenge) AfterFind(tx *gorm.DB) error {
+	c.SportTypeIDs = make([]int64, 0)
+	for _, s := range strings.Split(c.SportTypes, ",") {
+		if id, err := strconv.ParseInt(s, 10, 64); err == nil {
+			c.SportTypeIDs = append(c.SportTypeIDs, id)
+		}
+	}
+	c.Status = c.StatusAt(time.Now())
+	return nil
+}
+
+// StatusAt 计算挑战在某一时刻的状态
+func (c *Challenge) StatusAt(now time.Time) string {
+	switch {
+	case c.FinalizedAt != nil || !now.Before(c.EndTime):
+		return ChallengeStatusClosed
+	case now.Before(c.StartTime):
+		return ChallengeStatusUpcoming
+	default:
+		return ChallengeStatusActive
+	}
+}
+
+// ChallengeParticipant 挑战参与者及其进度，进度随运动记录的增删改增量更新
+type ChallengeParticipant struct {
+	ChallengeID uint64      `gorm:"primaryKey;autoIncrement:false;index:idx_challenge_participants_progress,priority:1" json:"challenge_id"`
+	UserID      int64       `gorm:"primaryKey;autoIncrement:false;index" json:"user_id"`
+	User        *PublicUser `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	TeamID      *uint64     `gorm:"index" json:"team_id"` // 团队挑战中所属的群组
+	Progress    float64     `gorm:"not null;default:0;index:idx_challenge_participants_progress,priority:2" json:"progress"`
+	RecordCount int64       `gorm:"not null;default:0" json:"record_count"` // 计入进度的运动记录数
+	CompletedAt *time.Time  `json:"completed_at"`                           // 达成目标的时间
+	FinalRank   *int        `json:"final_rank"`                             // 结算后的最终排名
+	Rank        int         `gorm:"-" json:"rank"`                          // 当前排名，并列时名次相同
+	CreatedAt   time.Time   `json:"joined_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (ChallengeParticipant) TableName() string {
+	return "challenge_participants"
+}
+
+// ChallengeTeam 团队挑战中的队伍，进度为队员进度之和
+type ChallengeTeam struct {
+	ChallengeID uint64     `gorm:"primaryKey;autoIncrement:false;index:idx_challenge_teams_progress,priority:1" json:"challenge_id"`
+	GroupID     uint64     `gorm:"primaryKey;autoIncrement:false" json:"group_id"`
+	Group       *Group     `gorm:"foreignKey:GroupID" json:"group,omitempty"`
+	MemberCount int64      `gorm:"not null;default:0" json:"member_count"` // 参与挑战的队员数
+	Progress    float64    `gorm:"not null;default:0;index:idx_challenge_teams_progress,priority:2" json:"progress"`
+	CompletedAt *time.Time `json:"completed_at"`
+	FinalRank   *int       `json:"final_rank"`
+	Rank        int        `gorm:"-" json:"rank"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (ChallengeTeam) TableName() string {
+	return "challenge_teams"
+}
@@ -3,17 +3,21 @@ package models
 // CheckIn 打卡记录模型
 type CheckIn struct {
 	BaseModel
-	UserID      uint64    `gorm:"not null;index" json:"user_id"`
-	User        User      `gorm:"foreignKey:UserID" json:"user"`
-	SportTypeID uint64    `gorm:"not null" json:"sport_type_id"`
-	SportType   SportType `gorm:"foreignKey:SportTypeID" json:"sport_type"`
-	Duration    int       `gorm:"not null" json:"duration"` // 运动时长（分钟）
-	Images      string    `gorm:"type:text" json:"images"`  // 图片URL，多个用逗号分隔
-	Description string    `gorm:"type:text" json:"description"`
-	IsShared    bool      `gorm:"default:false" json:"is_shared"`
+	UserID       uint64     `gorm:"not null;index" json:"user_id"`
+	User         PublicUser `gorm:"foreignKey:UserID" json:"user"`
+	SportTypeID  uint64     `gorm:"not null" json:"sport_type_id"`
+	SportType    SportType  `gorm:"foreignKey:SportTypeID" json:"sport_type"`
+	Duration     int        `gorm:"not null" json:"duration"` // 运动时长（分钟）
+	Images       string     `gorm:"type:text" json:"images"`  // 图片URL，多个用逗号分隔
+	Description  string     `gorm:"type:text" json:"description"`
+	Visibility   string     `gorm:"size:20;not null;default:'private'" json:"visibility"` // 可见范围：public/followers/private
+	IsHidden     bool       `gorm:"default:false" json:"is_hidden"`                       // 待审核或被管理员隐藏，仅作者可见
+	LikeCount    int64      `gorm:"not null;default:0" json:"like_count"`                 // 点赞数，随点赞/取消点赞在事务中更新
+	CommentCount int64      `gorm:"-" json:"comment_count"`                               // 评论数，读取时计算
+	LikedByMe    bool       `gorm:"-" json:"liked_by_me"`                                 // 当前用户是否已点赞
 }
 
 // TableName 指定表名
 func (CheckIn) TableName() string {
 	return "check_ins"
-} 
\ No newline at end of file
+}
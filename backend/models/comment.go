@@ -1,18 +1,32 @@
 package models
 
+import "time"
+
+// DeletedCommentPlaceholder 已删除但仍有回复的评论显示的内容
+const DeletedCommentPlaceholder = "该评论已删除"
+
+// HiddenCommentPlaceholder 待审核或被隐藏的评论对其他用户显示的内容
+const HiddenCommentPlaceholder = "该评论暂不可见"
+
 // Comment 评论模型
 type Comment struct {
 	BaseModel
-	UserID    uint64  `gorm:"not null" json:"user_id"`
-	User      User    `gorm:"foreignKey:UserID" json:"user"`
-	CheckInID uint64  `gorm:"not null;index" json:"check_in_id"`
-	CheckIn   CheckIn `gorm:"foreignKey:CheckInID" json:"check_in"`
-	Content   string  `gorm:"type:text;not null" json:"content"`
-	ParentID  *uint64 `gorm:"index" json:"parent_id"`
-	Parent    *Comment `gorm:"foreignKey:ParentID" json:"parent"`
+	UserID    uint64      `gorm:"not null" json:"user_id"`
+	User      PublicUser  `gorm:"foreignKey:UserID" json:"user"`
+	CheckInID uint64      `gorm:"not null;index" json:"check_in_id"`
+	CheckIn   CheckIn     `gorm:"foreignKey:CheckInID" json:"-"`
+	Content   string      `gorm:"type:text;not null" json:"content"`
+	ParentID  *uint64     `gorm:"index" json:"parent_id"`
+	Parent    *Comment    `gorm:"foreignKey:ParentID" json:"-"`
+	RootID    *uint64     `gorm:"index" json:"root_id"`            // 所属顶层评论，顶层评论为空
+	IsDeleted bool        `gorm:"default:false" json:"is_deleted"` // 已删除但保留占位（仍有回复时）
+	IsHidden  bool        `gorm:"default:false" json:"is_hidden"`  // 待审核或被管理员隐藏，仅作者可见
+	EditedAt  *time.Time  `json:"edited_at"`
+	Replies   []Comment   `gorm:"-" json:"replies,omitempty"`  // 树形展示时的子回复
+	ReplyTo   *PublicUser `gorm:"-" json:"reply_to,omitempty"` // 平铺展示时被回复的用户
 }
 
 // TableName 指定表名
 func (Comment) TableName() string {
 	return "comments"
-} 
\ No newline at end of file
+}
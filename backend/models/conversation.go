@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// 私信权限，决定谁可以向用户发起私信
+const (
+	MessagePrivacyEveryone  = "everyone"  // 所有人
+	MessagePrivacyFollowing = "following" // 仅我关注的人
+	MessagePrivacyNobody    = "nobody"    // 不接收新私信
+)
+
+// Conversation 两个用户之间的私信会话，每对用户只有一个会话
+// UserLowID 为两人中较小的用户ID，配合唯一索引保证并发创建时不重复
+type Conversation struct {
+	ID            uint64    `gorm:"primaryKey" json:"id"`
+	UserLowID     int64     `gorm:"not null;uniqueIndex:uk_conversation_users" json:"user_low_id"`
+	UserHighID    int64     `gorm:"not null;uniqueIndex:uk_conversation_users;index" json:"user_high_id"`
+	LastMessageID uint64    `gorm:"not null;default:0" json:"last_message_id"` // 最新一条私信ID
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (Conversation) TableName() string {
+	return "conversations"
+}
+
+// ConversationMember 会话参与者各自的会话状态，包括未读数和已读位置
+type ConversationMember struct {
+	ConversationID        uint64      `gorm:"primaryKey;autoIncrement:false" json:"conversation_id"`
+	UserID                int64       `gorm:"primaryKey;autoIncrement:false;index:idx_conversation_members_user_last" json:"-"`
+	PeerID                int64       `gorm:"not null" json:"peer_id"`                                         // 会话对方
+	Peer                  *PublicUser `gorm:"foreignKey:PeerID" json:"peer,omitempty"`                         // 会话对方信息
+	UnreadCount           int64       `gorm:"not null;default:0" json:"unread_count"`                          // 未读私信数
+	LastReadMessageID     uint64      `gorm:"not null;default:0" json:"last_read_message_id"`                  // 已读到的私信ID
+	LastMessageAt         *time.Time  `gorm:"index:idx_conversation_members_user_last" json:"last_message_at"` // 最新私信时间，为空表示还没有私信
+	LastMessage           *Message    `gorm:"-" json:"last_message"`                                           // 最新一条私信
+	PeerLastReadMessageID uint64      `gorm:"-" json:"peer_last_read_message_id"`                              // 对方已读到的私信ID，用于展示已读回执
+	CreatedAt             time.Time   `json:"created_at"`
+	UpdatedAt             time.Time   `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (ConversationMember) TableName() string {
+	return "conversation_members"
+}
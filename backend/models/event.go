@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// 活动状态
+const (
+	EventStatusScheduled = "scheduled" // 报名中或已开始
+	EventStatusCanceled  = "canceled"  // 已取消
+)
+
+// 报名状态
+const (
+	RSVPGoing      = "going"      // 参加
+	RSVPMaybe      = "maybe"      // 可能参加
+	RSVPDeclined   = "declined"   // 不参加
+	RSVPWaitlisted = "waitlisted" // 名额已满，候补中
+)
+
+// Event 活动模型，GroupID 为空时为公开活动，否则仅群组成员可见
+type Event struct {
+	ID            uint64      `gorm:"primaryKey" json:"id"`
+	GroupID       *uint64     `gorm:"index" json:"group_id"`
+	OrganizerID   int64       `gorm:"not null;index" json:"organizer_id"`
+	Organizer     *PublicUser `gorm:"foreignKey:OrganizerID" json:"organizer,omitempty"`
+	Title         string      `gorm:"size:100;not null" json:"title"`
+	Description   string      `gorm:"size:2000" json:"description"`
+	SportTypeID   int64       `gorm:"not null" json:"sport_type_id"`
+	SportType     *SportType  `gorm:"foreignKey:SportTypeID" json:"sport_type,omitempty"`
+	Location      string      `gorm:"size:200" json:"location"`
+	StartTime     time.Time   `gorm:"not null;index" json:"start_time"`
+	EndTime       time.Time   `gorm:"not null" json:"end_time"`
+	Capacity      int         `gorm:"not null;default:0" json:"capacity"` // 参加人数上限，0 表示不限
+	Status        string      `gorm:"size:20;not null;default:'scheduled'" json:"status"`
+	GoingCount    int         `gorm:"not null;default:0" json:"going_count"`    // 参加人数，随报名在事务中重新统计
+	MaybeCount    int         `gorm:"not null;default:0" json:"maybe_count"`    // 可能参加人数
+	WaitlistCount int         `gorm:"not null;default:0" json:"waitlist_count"` // 候补人数
+	MyRSVP        *EventRSVP  `gorm:"-" json:"my_rsvp"`                         // 当前用户的报名，未报名时为空
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (Event) TableName() string {
+	return "events"
+}
+
+// EventRSVP 活动报名，记录报名状态、签到及关联的运动记录
+type EventRSVP struct {
+	EventID       uint64      `gorm:"primaryKey;autoIncrement:false" json:"event_id"`
+	UserID        int64       `gorm:"primaryKey;autoIncrement:false;index" json:"user_id"`
+	User          *PublicUser `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Status        string      `gorm:"size:20;not null" json:"status"`
+	WaitlistedAt  *time.Time  `json:"waitlisted_at"`                          // 进入候补的时间，候补按此先后转正
+	Attended      bool        `gorm:"not null;default:false" json:"attended"` // 组织者确认已到场
+	CheckedInAt   *time.Time  `json:"checked_in_at"`                          // 签到确认时间
+	SportRecordID *int64      `gorm:"uniqueIndex" json:"sport_record_id"`     // 参加活动产生的运动记录
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (EventRSVP) TableName() string {
+	return "event_rsvps"
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Follow 用户关注关系模型
+type Follow struct {
+	ID         uint64      `gorm:"primaryKey" json:"id"`
+	FollowerID int64       `gorm:"not null;uniqueIndex:uk_follower_followee" json:"follower_id"`       // 关注者
+	Follower   *PublicUser `gorm:"foreignKey:FollowerID" json:"follower,omitempty"`                    // 关注者信息
+	FolloweeID int64       `gorm:"not null;uniqueIndex:uk_follower_followee;index" json:"followee_id"` // 被关注者
+	Followee   *PublicUser `gorm:"foreignKey:FolloweeID" json:"followee,omitempty"`                    // 被关注者信息
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// TableName 指定表名
+func (Follow) TableName() string {
+	return "follows"
+}
+
+// FollowCounts 用户的关注数与粉丝数
+type FollowCounts struct {
+	FollowerCount  int64 `json:"follower_count"`
+	FollowingCount int64 `json:"following_count"`
+}
+
+// UserProfile 用户公开资料
+type UserProfile struct {
+	PublicUser
+	FollowCounts
+	IsFollowing   bool `json:"is_following"`   // 当前用户是否已关注
+	IsBlocking    bool `json:"is_blocking"`    // 当前用户是否已屏蔽对方
+	IsMuting      bool `json:"is_muting"`      // 当前用户是否已静音对方
+	ProfileHidden bool `json:"profile_hidden"` // 对方设置了资料可见范围，关注数据不可见
+}
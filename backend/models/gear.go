@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 装备类型
+const (
+	GearTypeShoes  = "shoes"  // 跑鞋
+	GearTypeBike   = "bike"   // 自行车
+	GearTypeRacket = "racket" // 球拍
+	GearTypeOther  = "other"  // 其他
+)
+
+// Gear 运动装备模型
+type Gear struct {
+	ID                 int64          `gorm:"primaryKey" json:"id"`
+	UserID             int64          `gorm:"not null;index" json:"user_id"`
+	Name               string         `gorm:"size:50;not null" json:"name"`
+	Type               string         `gorm:"size:20;not null" json:"type"`
+	Brand              string         `gorm:"size:50" json:"brand"`
+	PurchaseDate       *time.Time     `json:"purchase_date"`
+	RetirementDistance float64        `json:"retirement_distance"` // 建议退役里程（公里），0 表示不限制
+	RetirementDuration int64          `json:"retirement_duration"` // 建议退役时长（分钟），0 表示不限制
+	RetiredAt          *time.Time     `json:"retired_at"`
+	TotalDistance      float64        `gorm:"-" json:"total_distance"`  // 累计里程（公里），读取时计算
+	TotalDuration      int64          `gorm:"-" json:"total_duration"`  // 累计时长（分钟），读取时计算
+	RecordCount        int64          `gorm:"-" json:"record_count"`    // 使用次数，读取时计算
+	UsageRatio         float64        `gorm:"-" json:"usage_ratio"`     // 已使用比例（相对退役阈值）
+	NearRetirement     bool           `gorm:"-" json:"near_retirement"` // 是否接近退役
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName 指定表名
+func (Gear) TableName() string {
+	return "gear"
+}
+
+// GearMonthlyUsage 装备按月使用情况
+type GearMonthlyUsage struct {
+	Month    string  `json:"month"` // 格式 2006-01
+	Distance float64 `json:"distance"`
+	Duration int64   `json:"duration"`
+	Count    int64   `json:"count"`
+}
+
+// GearStats 单件装备的统计信息
+type GearStats struct {
+	Gear            Gear               `json:"gear"`
+	FirstUsedAt     *time.Time         `json:"first_used_at"`
+	LastUsedAt      *time.Time         `json:"last_used_at"`
+	AverageDistance float64            `json:"average_distance"` // 平均每次里程（公里）
+	Monthly         []GearMonthlyUsage `json:"monthly"`
+}
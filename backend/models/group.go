@@ -0,0 +1,99 @@
+package models
+
+import "time"
+
+// 群组加入方式
+const (
+	GroupJoinOpen     = "open"     // 直接加入
+	GroupJoinApproval = "approval" // 申请后由管理员审批
+	GroupJoinInvite   = "invite"   // 仅能通过邀请码加入
+)
+
+// 群组成员角色
+const (
+	GroupRoleOwner  = "owner"  // 群主，每个群组只有一个
+	GroupRoleAdmin  = "admin"  // 管理员
+	GroupRoleMember = "member" // 普通成员
+)
+
+// 入群申请状态
+const (
+	GroupRequestPending  = "pending"  // 待审批
+	GroupRequestApproved = "approved" // 已通过
+	GroupRequestRejected = "rejected" // 已拒绝
+)
+
+// Group 群组（俱乐部）模型
+type Group struct {
+	ID          uint64      `gorm:"primaryKey" json:"id"`
+	Name        string      `gorm:"size:50;not null" json:"name"`
+	Description string      `gorm:"size:500" json:"description"`
+	AvatarURL   string      `gorm:"size:255" json:"avatar_url"`
+	OwnerID     int64       `gorm:"not null;index" json:"owner_id"`
+	Owner       *PublicUser `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
+	JoinMode    string      `gorm:"size:20;not null;default:'open'" json:"join_mode"`
+	InviteCode  string      `gorm:"size:16;not null;uniqueIndex" json:"invite_code,omitempty"` // 邀请码，仅群主和管理员可见
+	MemberCount int64       `gorm:"not null;default:0" json:"member_count"`                    // 成员数，随加入/退出在事务中更新
+	MyRole      string      `gorm:"-" json:"my_role"`                                          // 当前用户的角色，未加入时为空
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (Group) TableName() string {
+	return "sport_groups" // groups 是 MySQL 8 的保留字
+}
+
+// GroupMember 群组成员
+type GroupMember struct {
+	GroupID   uint64      `gorm:"primaryKey;autoIncrement:false" json:"group_id"`
+	UserID    int64       `gorm:"primaryKey;autoIncrement:false;index" json:"user_id"`
+	User      *PublicUser `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Role      string      `gorm:"size:20;not null;default:'member'" json:"role"`
+	CreatedAt time.Time   `json:"joined_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (GroupMember) TableName() string {
+	return "group_members"
+}
+
+// GroupJoinRequest 入群申请，同一用户对同一群组只保留一条申请
+type GroupJoinRequest struct {
+	ID        uint64      `gorm:"primaryKey" json:"id"`
+	GroupID   uint64      `gorm:"not null;uniqueIndex:uk_group_request_user" json:"group_id"`
+	UserID    int64       `gorm:"not null;uniqueIndex:uk_group_request_user" json:"user_id"`
+	User      *PublicUser `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Message   string      `gorm:"size:200" json:"message"` // 申请留言
+	Status    string      `gorm:"size:20;not null;default:'pending'" json:"status"`
+	HandledBy *int64      `json:"handled_by"`
+	HandledAt *time.Time  `json:"handled_at"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (GroupJoinRequest) TableName() string {
+	return "group_join_requests"
+}
+
+// GroupStats 群组成员运动数据汇总
+type GroupStats struct {
+	MemberCount   int64                 `json:"member_count"`
+	ActiveMembers int64                 `json:"active_members"` // 统计范围内有运动记录的成员数
+	RecordCount   int64                 `json:"record_count"`
+	TotalDuration int64                 `json:"total_duration"` // 总运动时长（分钟）
+	TotalDistance float64               `json:"total_distance"` // 总距离（公里）
+	TotalCalories int64                 `json:"total_calories"`
+	SportTypes    []GroupSportTypeStats `json:"sport_types"` // 按运动类型汇总
+}
+
+// GroupSportTypeStats 群组按运动类型的汇总
+type GroupSportTypeStats struct {
+	SportTypeID   int64   `json:"sport_type_id"`
+	Name          string  `json:"name"`
+	RecordCount   int64   `json:"record_count"`
+	TotalDuration int64   `json:"total_duration"`
+	TotalDistance float64 `json:"total_distance"`
+}
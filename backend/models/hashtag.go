@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Hashtag 话题
+type Hashtag struct {
+	ID        uint64    `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"size:50;not null;uniqueIndex" json:"name"` // 规范化后的话题名（小写、简体、半角）
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName 指定表名
+func (Hashtag) TableName() string {
+	return "hashtags"
+}
+
+// CheckInHashtag 打卡描述中出现的话题
+type CheckInHashtag struct {
+	CheckInID uint64    `gorm:"primaryKey;index:idx_check_in_hashtags_hashtag,priority:2" json:"check_in_id"`
+	HashtagID uint64    `gorm:"primaryKey;index:idx_check_in_hashtags_hashtag,priority:1" json:"hashtag_id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName 指定表名
+func (CheckInHashtag) TableName() string {
+	return "check_in_hashtags"
+}
+
+// CommentHashtag 评论内容中出现的话题
+type CommentHashtag struct {
+	CommentID uint64    `gorm:"primaryKey;index:idx_comment_hashtags_hashtag,priority:2" json:"comment_id"`
+	HashtagID uint64    `gorm:"primaryKey;index:idx_comment_hashtags_hashtag,priority:1" json:"hashtag_id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName 指定表名
+func (CommentHashtag) TableName() string {
+	return "comment_hashtags"
+}
+
+// TrendingHashtag 热门话题及统计窗口内的使用次数
+type TrendingHashtag struct {
+	ID       uint64 `json:"id"`
+	Name     string `json:"name"`
+	UseCount int64  `json:"use_count"` // 窗口内使用该话题的公开打卡数与评论数之和
+}
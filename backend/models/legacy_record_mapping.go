@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// 旧数据来源表
+const (
+	LegacySourceExercise = "exercises"
+	LegacySourceCheckIn  = "check_ins"
+)
+
+// LegacyRecordMapping 旧表（exercises/check_ins）记录与运动记录的对应关系
+type LegacyRecordMapping struct {
+	ID          int64     `gorm:"primaryKey" json:"id"`
+	SourceTable string    `gorm:"size:30;not null;uniqueIndex:uk_legacy_source" json:"source_table"`
+	SourceID    int64     `gorm:"not null;uniqueIndex:uk_legacy_source" json:"source_id"`
+	RecordID    int64     `gorm:"not null;index" json:"record_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableName 指定表名
+func (LegacyRecordMapping) TableName() string {
+	return "legacy_record_mappings"
+}
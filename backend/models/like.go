@@ -3,13 +3,13 @@ package models
 // Like 点赞模型
 type Like struct {
 	BaseModel
-	UserID    uint64  `gorm:"not null;uniqueIndex:uk_user_check_in" json:"user_id"`
-	User      User    `gorm:"foreignKey:UserID" json:"user"`
-	CheckInID uint64  `gorm:"not null;uniqueIndex:uk_user_check_in" json:"check_in_id"`
-	CheckIn   CheckIn `gorm:"foreignKey:CheckInID" json:"check_in"`
+	UserID    uint64     `gorm:"not null;uniqueIndex:uk_user_check_in" json:"user_id"`
+	User      PublicUser `gorm:"foreignKey:UserID" json:"user"`
+	CheckInID uint64     `gorm:"not null;uniqueIndex:uk_user_check_in" json:"check_in_id"`
+	CheckIn   CheckIn    `gorm:"foreignKey:CheckInID" json:"-"`
 }
 
 // TableName 指定表名
 func (Like) TableName() string {
 	return "likes"
-} 
\ No newline at end of file
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Message 私信模型
+type Message struct {
+	ID             uint64    `gorm:"primaryKey" json:"id"`
+	ConversationID uint64    `gorm:"not null;index" json:"conversation_id"`
+	SenderID       int64     `gorm:"not null" json:"sender_id"`
+	RecipientID    int64     `gorm:"not null" json:"recipient_id"`
+	Content        string    `gorm:"type:text;not null" json:"content"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TableName 指定表名
+func (Message) TableName() string {
+	return "messages"
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// 管理员审核操作
+const (
+	ModerationActionHide          = "hide"           // 隐藏内容
+	ModerationActionRestore       = "restore"        // 恢复内容或解除封禁
+	ModerationActionDismiss       = "dismiss"        // 驳回举报
+	ModerationActionWarn          = "warn"           // 警告用户
+	ModerationActionSuspend       = "suspend"        // 封禁用户
+	ModerationActionApproveReview = "approve_review" // 敏感词审核通过
+	ModerationActionRejectReview  = "reject_review"  // 敏感词审核拒绝
+)
+
+// ModerationAction 管理员审核操作记录，只增不改
+type ModerationAction struct {
+	ID           uint64      `gorm:"primaryKey" json:"id"`
+	ModeratorID  int64       `gorm:"not null;index" json:"moderator_id"`
+	Moderator    *PublicUser `gorm:"foreignKey:ModeratorID" json:"moderator,omitempty"`
+	Action       string      `gorm:"size:20;not null" json:"action"`
+	TargetType   string      `gorm:"size:20;not null;index:idx_moderation_actions_target" json:"target_type"`
+	TargetID     uint64      `gorm:"not null;index:idx_moderation_actions_target" json:"target_id"`
+	TargetUserID int64       `gorm:"not null;index" json:"target_user_id"` // 受影响的用户
+	ReportID     *uint64     `json:"report_id"`                            // 由举报触发时的举报ID
+	ReviewID     *uint64     `json:"review_id"`                            // 由敏感词审核触发时的审核ID
+	Note         string      `gorm:"size:500" json:"note"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// TableName 指定表名
+func (ModerationAction) TableName() string {
+	return "moderation_actions"
+}
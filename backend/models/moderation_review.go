@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// 审核状态
+const (
+	ReviewStatusPending  = "pending"  // 待审核
+	ReviewStatusApproved = "approved" // 审核通过
+	ReviewStatusRejected = "rejected" // 审核拒绝
+)
+
+// 审核及举报对象类型
+const (
+	ModerationTargetCheckIn = "check_in"
+	ModerationTargetComment = "comment"
+	ModerationTargetUser    = "user"
+)
+
+// ModerationReview 命中敏感词、等待人工审核的内容
+type ModerationReview struct {
+	ID           uint64     `gorm:"primaryKey" json:"id"`
+	TargetType   string     `gorm:"size:20;not null;index:idx_reviews_target" json:"target_type"`
+	TargetID     uint64     `gorm:"not null;index:idx_reviews_target" json:"target_id"`
+	UserID       int64      `gorm:"not null;index" json:"user_id"` // 内容作者
+	Content      string     `gorm:"type:text" json:"content"`      // 提交审核时的原文
+	MatchedWords string     `gorm:"size:255" json:"matched_words"` // 命中的敏感词，逗号分隔
+	Status       string     `gorm:"size:20;not null;default:'pending';index" json:"status"`
+	ReviewerID   *int64     `json:"reviewer_id"`
+	ReviewedAt   *time.Time `json:"reviewed_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (ModerationReview) TableName() string {
+	return "moderation_reviews"
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// 通知类型
+const (
+	NotificationTypeLike    = "like"    // 点赞了你的打卡
+	NotificationTypeComment = "comment" // 评论了你的打卡
+	NotificationTypeReply   = "reply"   // 回复了你的评论
+	NotificationTypeFollow  = "follow"  // 关注了你
+	NotificationTypeMention = "mention" // 在打卡或评论中提到了你
+	NotificationTypeBadge   = "badge"   // 获得了勋章
+	NotificationTypeSystem  = "system"  // 系统通知，如违规警告、封禁
+
+	NotificationTypeEventPromoted = "event_promoted" // 活动候补转为参加
+	NotificationTypeEventCanceled = "event_canceled" // 报名的活动被取消
+)
+
+// 通知关联的对象类型
+const (
+	NotificationTargetCheckIn = "check_in"
+	NotificationTargetComment = "comment"
+	NotificationTargetUser    = "user"
+	NotificationTargetBadge   = "badge"
+	NotificationTargetEvent   = "event"
+)
+
+// Notification 站内通知模型
+// 同一对象上未读的同类通知会合并为一条，ActorCount 记录参与合并的不同用户数
+type Notification struct {
+	ID         uint64      `gorm:"primaryKey" json:"id"`
+	UserID     int64       `gorm:"not null;index:idx_notifications_user_read" json:"user_id"` // 接收者
+	Type       string      `gorm:"size:20;not null" json:"type"`
+	TargetType string      `gorm:"size:20;not null" json:"target_type"`
+	TargetID   uint64      `gorm:"not null" json:"target_id"`
+	GroupKey   string      `gorm:"size:100;not null;index" json:"-"`          // 合并键，如 like:check_in:12
+	ActorID    *int64      `json:"actor_id"`                                  // 最近一次触发的用户
+	Actor      *PublicUser `gorm:"foreignKey:ActorID" json:"actor,omitempty"` // 最近一次触发的用户信息
+	ActorCount int         `gorm:"not null;default:1" json:"actor_count"`     // 合并的不同用户数
+	Content    string      `gorm:"size:255" json:"content"`                   // 评论摘要、勋章名称等
+	Summary    string      `gorm:"-" json:"summary"`                          // 展示文案，读取时生成
+	IsRead     bool        `gorm:"not null;default:false;index:idx_notifications_user_read" json:"is_read"`
+	ReadAt     *time.Time  `json:"read_at"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `gorm:"index" json:"updated_at"` // 最近一次合并的时间
+}
+
+// TableName 指定表名
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// NotificationActor 记录参与合并通知的用户，用于去重计数
+type NotificationActor struct {
+	NotificationID uint64    `gorm:"primaryKey" json:"notification_id"`
+	ActorID        int64     `gorm:"primaryKey" json:"actor_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TableName 指定表名
+func (NotificationActor) TableName() string {
+	return "notification_actors"
+}
@@ -0,0 +1,12 @@
+package models
+
+// PublicUser 对外展示的用户信息，不包含邮箱等隐私字段
+type PublicUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+// TableName 指定表名
+func (PublicUser) TableName() string {
+	return "users"
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// RecordSet 运动记录中的单组力量训练
+type RecordSet struct {
+	ID                 int64            `gorm:"primaryKey" json:"id"`
+	RecordID           int64            `gorm:"not null;index" json:"record_id"`
+	StrengthExerciseID int64            `gorm:"not null;index" json:"strength_exercise_id"`
+	StrengthExercise   StrengthExercise `gorm:"foreignKey:StrengthExerciseID" json:"strength_exercise"`
+	SetIndex           int              `gorm:"not null" json:"set_index"` // 组序号，从1开始
+	Reps               int              `gorm:"not null" json:"reps"`      // 次数
+	Weight             float64          `gorm:"not null" json:"weight"`    // 重量（千克）
+	RestSeconds        int              `json:"rest_seconds"`              // 组间休息（秒）
+	RPE                *int             `gorm:"column:rpe" json:"rpe"`     // 主观疲劳度（1-10）
+	CreatedAt          time.Time        `json:"created_at"`
+	UpdatedAt          time.Time        `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (RecordSet) TableName() string {
+	return "record_sets"
+}
+
+// Volume 单组训练量（次数 × 重量）
+func (s RecordSet) Volume() float64 {
+	return float64(s.Reps) * s.Weight
+}
+
+// EstimatedOneRM 使用 Epley 公式估算单组对应的最大重量
+func (s RecordSet) EstimatedOneRM() float64 {
+	if s.Reps <= 0 {
+		return 0
+	}
+	if s.Reps == 1 {
+		return s.Weight
+	}
+	return s.Weight * (1 + float64(s.Reps)/30)
+}
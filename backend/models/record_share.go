@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// RecordShare 运动记录的公开分享链接，持有 token 的人无需登录即可查看记录摘要
+type RecordShare struct {
+	ID        uint64     `gorm:"primaryKey" json:"id"`
+	RecordID  int64      `gorm:"not null;index" json:"record_id"`
+	UserID    int64      `gorm:"not null;index" json:"user_id"`
+	Token     string     `gorm:"size:64;not null;uniqueIndex" json:"token"` // 随机生成，不可猜测
+	ExpiresAt *time.Time `json:"expires_at"`                                // 过期时间，为空表示长期有效
+	RevokedAt *time.Time `json:"revoked_at"`                                // 撤销时间，撤销后链接失效
+	ViewCount int64      `gorm:"not null;default:0" json:"view_count"`      // 访问次数
+	URL       string     `gorm:"-" json:"url"`                              // 分享页地址
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName 指定表名
+func (RecordShare) TableName() string {
+	return "record_shares"
+}
+
+// SharedRecord 分享页展示的运动记录摘要，不包含备注、心率等个人数据
+type SharedRecord struct {
+	User        PublicUser `json:"user"`
+	SportType   string     `json:"sport_type"`
+	Exercise    string     `json:"exercise"`
+	StartTime   time.Time  `json:"start_time"`
+	EndTime     time.Time  `json:"end_time"`
+	Duration    int64      `json:"duration"` // 运动时长（分钟）
+	Distance    float64    `json:"distance"` // 运动距离（公里）
+	Calories    int64      `json:"calories"`
+	Images      []string   `json:"images"`
+	Environment string     `json:"environment"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	URL         string     `json:"url"`      // 分享页地址
+	CardURL     string     `json:"card_url"` // 分享卡片图片地址
+}
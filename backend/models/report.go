@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// 举报原因
+const (
+	ReportReasonSpam    = "spam"    // 垃圾广告
+	ReportReasonAbuse   = "abuse"   // 辱骂骚扰
+	ReportReasonSexual  = "sexual"  // 色情低俗
+	ReportReasonIllegal = "illegal" // 违法违规
+	ReportReasonOther   = "other"   // 其他
+)
+
+// 举报状态
+const (
+	ReportStatusPending   = "pending"   // 待处理
+	ReportStatusResolved  = "resolved"  // 已处理
+	ReportStatusDismissed = "dismissed" // 已驳回
+)
+
+// Report 用户举报
+type Report struct {
+	ID           uint64      `gorm:"primaryKey" json:"id"`
+	ReporterID   int64       `gorm:"not null;uniqueIndex:uk_reporter_target" json:"reporter_id"`
+	Reporter     *PublicUser `gorm:"foreignKey:ReporterID" json:"reporter,omitempty"`
+	TargetType   string      `gorm:"size:20;not null;uniqueIndex:uk_reporter_target;index:idx_reports_target" json:"target_type"`
+	TargetID     uint64      `gorm:"not null;uniqueIndex:uk_reporter_target;index:idx_reports_target" json:"target_id"`
+	TargetUserID int64       `gorm:"not null;index" json:"target_user_id"` // 被举报内容的作者
+	TargetUser   *PublicUser `gorm:"foreignKey:TargetUserID" json:"target_user,omitempty"`
+	Reason       string      `gorm:"size:20;not null" json:"reason"`
+	Details      string      `gorm:"size:500" json:"details"`
+	Status       string      `gorm:"size:20;not null;default:'pending';index" json:"status"`
+	Action       string      `gorm:"size:20" json:"action"` // 处理时采取的操作
+	ResolvedBy   *int64      `json:"resolved_by"`
+	ResolvedAt   *time.Time  `json:"resolved_at"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+// TableName 指定表名
+func (Report) TableName() string {
+	return "reports"
+}
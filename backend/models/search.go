@@ -0,0 +1,31 @@
+package models
+
+// 搜索结果类型
+const (
+	SearchTypeRecords    = "records"
+	SearchTypeCheckIns   = "check_ins"
+	SearchTypeUsers      = "users"
+	SearchTypeSportTypes = "sport_types"
+)
+
+// SearchHit 单条搜索结果
+type SearchHit struct {
+	ID         uint64            `json:"id"`
+	Highlights map[string]string `json:"highlights"` // 命中字段的片段，匹配部分用 <em></em> 标记，其余内容已做 HTML 转义
+	Data       interface{}       `json:"data"`       // 运动记录、打卡、用户或运动类型
+}
+
+// SearchGroup 按类型分组的搜索结果
+type SearchGroup struct {
+	Type  string      `json:"type"`
+	Total int64       `json:"total"`
+	Items []SearchHit `json:"items"`
+}
+
+// SearchResult 搜索结果
+type SearchResult struct {
+	Query    string        `json:"query"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"page_size"`
+	Groups   []SearchGroup `json:"groups"`
+}
@@ -4,24 +4,89 @@ import (
 	"time"
 )
 
+// 运动环境
+const (
+	EnvironmentIndoor  = "indoor"  // 室内
+	EnvironmentOutdoor = "outdoor" // 户外
+)
+
+// 运动后心情
+const (
+	MoodGreat   = "great"   // 很棒
+	MoodGood    = "good"    // 不错
+	MoodNeutral = "neutral" // 一般
+	MoodTired   = "tired"   // 疲惫
+	MoodBad     = "bad"     // 糟糕
+)
+
+// 可见范围，适用于运动记录、打卡和个人资料
+const (
+	VisibilityPublic    = "public"    // 公开
+	VisibilityFollowers = "followers" // 仅关注者可见
+	VisibilityPrivate   = "private"   // 仅自己可见
+)
+
 // SportRecord 运动记录模型
 type SportRecord struct {
-	ID          int64     `json:"id" gorm:"primaryKey"`
-	UserID      int64     `json:"user_id"`
-	SportTypeID int64     `json:"sport_type_id" gorm:"not null"`
-	SportType   SportType `json:"sport_type" gorm:"foreignKey:SportTypeID"`
-	Exercise    string    `json:"exercise"`
-	Duration    int64     `json:"duration"`
-	Calories    int64     `json:"calories"`
-	StartTime   time.Time `json:"start_time" gorm:"not null"`
-	EndTime     time.Time `json:"end_time"`
-	ImageURL    string    `json:"image_url" gorm:"size:255"`
-	ImgURLList  string    `json:"img_url_list" gorm:"type:json"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           int64         `json:"id" gorm:"primaryKey"`
+	UserID       int64         `json:"user_id"`
+	SportTypeID  int64         `json:"sport_type_id" gorm:"not null"`
+	SportType    SportType     `json:"sport_type" gorm:"foreignKey:SportTypeID"`
+	Exercise     string        `json:"exercise"`
+	Duration     int64         `json:"duration"`
+	Calories     int64         `json:"calories"`
+	Distance     float64       `json:"distance"` // 运动距离（公里）
+	StartTime    time.Time     `json:"start_time" gorm:"not null"`
+	EndTime      time.Time     `json:"end_time"`
+	ImageURL     string        `json:"image_url" gorm:"size:255"`
+	ImgURLList   string        `json:"img_url_list" gorm:"type:json"`
+	RPE          *int          `json:"rpe" gorm:"column:rpe"`                                // 主观疲劳度（1-10）
+	Mood         string        `json:"mood" gorm:"size:20"`                                  // 运动后心情
+	Notes        string        `json:"notes" gorm:"type:text"`                               // 备注
+	Environment  string        `json:"environment" gorm:"size:10"`                           // 室内/户外
+	AvgHeartRate *int          `json:"avg_heart_rate" gorm:"column:avg_heart_rate"`          // 平均心率（次/分）
+	MaxHeartRate *int          `json:"max_heart_rate" gorm:"column:max_heart_rate"`          // 最大心率（次/分）
+	GearID       *int64        `json:"gear_id" gorm:"index"`                                 // 使用的装备
+	Visibility   string        `json:"visibility" gorm:"size:20;not null;default:'private'"` // 可见范围
+	Sets         []RecordSet   `json:"sets" gorm:"foreignKey:RecordID"`                      // 力量训练分组
+	TotalVolume  float64       `json:"total_volume" gorm:"-"`                                // 力量训练总训练量（千克），读取时计算
+	Lifts        []LiftSummary `json:"lifts,omitempty" gorm:"-"`                             // 各动作汇总，读取时计算
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
 }
 
 // TableName 设置表名
 func (SportRecord) TableName() string {
 	return "sport_records"
-} 
\ No newline at end of file
+}
+
+// ComputeStrengthSummary 根据已加载的分组计算总训练量及各动作的估算1RM
+func (r *SportRecord) ComputeStrengthSummary() {
+	r.TotalVolume = 0
+	r.Lifts = nil
+
+	index := make(map[int64]int)
+	for _, set := range r.Sets {
+		i, ok := index[set.StrengthExerciseID]
+		if !ok {
+			i = len(r.Lifts)
+			index[set.StrengthExerciseID] = i
+			r.Lifts = append(r.Lifts, LiftSummary{
+				StrengthExerciseID: set.StrengthExerciseID,
+				Name:               set.StrengthExercise.Name,
+			})
+		}
+
+		lift := &r.Lifts[i]
+		lift.Sets++
+		lift.TotalReps += set.Reps
+		lift.TotalVolume += set.Volume()
+		if set.Weight > lift.MaxWeight {
+			lift.MaxWeight = set.Weight
+		}
+		if oneRM := set.EstimatedOneRM(); oneRM > lift.EstimatedOneRM {
+			lift.EstimatedOneRM = oneRM
+		}
+		r.TotalVolume += set.Volume()
+	}
+}
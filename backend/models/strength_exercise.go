@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StrengthExercise 力量训练动作库（卧推、深蹲等）
+type StrengthExercise struct {
+	ID          int64          `gorm:"primaryKey" json:"id"`
+	UserID      *int64         `gorm:"index" json:"user_id"` // 为空表示系统内置动作，否则为用户自定义动作
+	Name        string         `gorm:"size:50;not null" json:"name"`
+	Category    string         `gorm:"size:30;index" json:"category"` // 目标肌群，如 chest/legs/back
+	Equipment   string         `gorm:"size:30" json:"equipment"`      // 器械，如 barbell/dumbbell
+	Description string         `gorm:"type:text" json:"description"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName 指定表名
+func (StrengthExercise) TableName() string {
+	return "strength_exercises"
+}
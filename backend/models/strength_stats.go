@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// LiftSummary 单条记录中某个动作的汇总
+type LiftSummary struct {
+	StrengthExerciseID int64   `json:"strength_exercise_id"`
+	Name               string  `json:"name"`
+	Sets               int     `json:"sets"`             // 组数
+	TotalReps          int     `json:"total_reps"`       // 总次数
+	TotalVolume        float64 `json:"total_volume"`     // 总训练量（千克）
+	MaxWeight          float64 `json:"max_weight"`       // 最大重量（千克）
+	EstimatedOneRM     float64 `json:"estimated_one_rm"` // 估算1RM（千克）
+}
+
+// ProgressionPoint 动作进步曲线上的一个数据点（每条记录一个点）
+type ProgressionPoint struct {
+	RecordID       int64     `json:"record_id"`
+	Date           time.Time `json:"date"`
+	MaxWeight      float64   `json:"max_weight"`
+	TotalVolume    float64   `json:"total_volume"`
+	EstimatedOneRM float64   `json:"estimated_one_rm"`
+}
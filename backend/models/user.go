@@ -19,6 +19,16 @@ type User struct {
 	Role      string           `gorm:"default:'user'" json:"role"`
 	Timezone  string           `gorm:"default:'Asia/Shanghai'" json:"timezone"`
 	LastLoginAt time.Time      `json:"last_login_at"`
+	SuspendedUntil *time.Time  `json:"suspended_until"` // 封禁到期时间，封禁期间不能发布内容和互动
+	MessagePrivacy string      `gorm:"size:20;not null;default:'everyone'" json:"message_privacy"` // 私信权限：everyone/following/nobody
+	DefaultRecordVisibility  string `gorm:"size:20;not null;default:'private'" json:"default_record_visibility"`   // 新运动记录的默认可见范围
+	DefaultCheckInVisibility string `gorm:"size:20;not null;default:'private'" json:"default_check_in_visibility"` // 新打卡的默认可见范围
+	ProfileVisibility        string `gorm:"size:20;not null;default:'public'" json:"profile_visibility"`           // 关注数据等资料的可见范围
+}
+
+// IsSuspended 判断用户当前是否处于封禁期
+func (u *User) IsSuspended() bool {
+	return u.SuspendedUntil != nil && u.SuspendedUntil.After(time.Now())
 }
 
 // TableName 指定表名
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WorkoutTemplate 训练模板（常用训练快捷添加）
+type WorkoutTemplate struct {
+	ID          int64          `gorm:"primaryKey" json:"id"`
+	UserID      int64          `gorm:"not null;index" json:"user_id"`
+	Name        string         `gorm:"size:50;not null" json:"name"`
+	SportTypeID int64          `gorm:"not null" json:"sport_type_id"`
+	SportType   SportType      `gorm:"foreignKey:SportTypeID" json:"sport_type"`
+	Exercise    string         `gorm:"size:255" json:"exercise"`
+	Duration    int64          `json:"duration"` // 常规时长（分钟）
+	Calories    int64          `json:"calories"`
+	Notes       string         `gorm:"type:text" json:"notes"`
+	UsageCount  int64          `gorm:"not null;default:0" json:"usage_count"` // 使用次数
+	LastUsedAt  *time.Time     `json:"last_used_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName 指定表名
+func (WorkoutTemplate) TableName() string {
+	return "workout_templates"
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"sports-app/backend/controllers"
+	"sports-app/backend/middleware"
 	"sports-app/backend/services"
 
 	"github.com/gin-gonic/gin"
@@ -12,13 +13,15 @@ import (
 func SetupAuthRoutes(r *gin.Engine, db *gorm.DB) {
 	verificationService := services.NewVerificationService(db)
 	authService := services.NewAuthService(db, verificationService)
-	authController := controllers.NewAuthController(authService)
+	authController := controllers.NewAuthController(authService, services.NewSessionService(db))
 
 	auth := r.Group("/api/auth")
 	{
 		auth.POST("/register", authController.Register)
 		auth.POST("/login", authController.Login)
-		auth.POST("/logout", authController.Logout)
+		auth.POST("/refresh", authController.Refresh)
+		auth.POST("/logout", middleware.AuthMiddleware(db), authController.Logout)
+		auth.POST("/logout-all", middleware.AuthMiddleware(db), authController.LogoutAll)
 		auth.POST("/send-reset-code", authController.SendCode)
 		auth.POST("/verify-code", authController.VerifyCode)
 		auth.POST("/reset-password", authController.ResetPassword)
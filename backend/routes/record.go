@@ -5,13 +5,14 @@ import (
 	"sports-app/backend/middleware"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // RegisterRecordRoutes 注册运动记录相关路由
-func RegisterRecordRoutes(r *gin.Engine, recordController *controllers.RecordController) {
+func RegisterRecordRoutes(r *gin.Engine, db *gorm.DB, recordController *controllers.RecordController) {
 	// 需要认证的路由组
 	authGroup := r.Group("/api")
-	authGroup.Use(middleware.AuthMiddleware())
+	authGroup.Use(middleware.AuthMiddleware(db))
 
 	// 运动记录路由
 	authGroup.GET("/records", recordController.GetRecords)
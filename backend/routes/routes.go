@@ -34,15 +34,53 @@ func SetupRoutes(r *gin.Engine, db *gorm.DB, logsDB *gorm.DB) {
 	// 创建服务实例
 	verificationService := services.NewVerificationService(logsDB)
 	authService := services.NewAuthService(db, verificationService)
+	sessionService := services.NewSessionService(db)
 	recordService := services.NewRecordService(db)
 	sportTypeService := services.NewSportTypeService(db)
+	strengthService := services.NewStrengthService(db)
+	templateService := services.NewTemplateService(db)
+	gearService := services.NewGearService(db)
+	communityService := services.NewCommunityService(db)
+	commentService := services.NewCommentService(db)
+	likeService := services.NewLikeService(db)
+	hashtagService := services.NewHashtagService(db)
+	searchService := services.NewSearchService(db)
+	followService := services.NewFollowService(db)
+	notificationService := services.NewNotificationService(db)
+	messageService := services.NewMessageService(db)
+	groupService := services.NewGroupService(db)
+	eventService := services.NewEventService(db)
+	challengeService := services.NewChallengeService(db)
+	privacyService := services.NewPrivacyService(db)
+	blockService := services.NewBlockService(db)
+	shareService := services.NewShareService(db)
+	moderationService := services.NewModerationQueueService(db)
 	updateLogService := services.NewUpdateLogService(logsDB)
 
 	// 创建控制器实例
-	authController := controllers.NewAuthController(authService)
+	authController := controllers.NewAuthController(authService, sessionService)
 	recordController := controllers.NewRecordController(recordService)
 	userController := controllers.NewUserController(db)
 	sportTypeController := controllers.NewSportTypeController(sportTypeService)
+	strengthController := controllers.NewStrengthController(strengthService)
+	templateController := controllers.NewTemplateController(templateService)
+	gearController := controllers.NewGearController(gearService)
+	communityController := controllers.NewCommunityController(communityService)
+	commentController := controllers.NewCommentController(commentService)
+	likeController := controllers.NewLikeController(likeService)
+	hashtagController := controllers.NewHashtagController(hashtagService)
+	searchController := controllers.NewSearchController(searchService)
+	followController := controllers.NewFollowController(followService)
+	notificationController := controllers.NewNotificationController(notificationService)
+	messageController := controllers.NewMessageController(messageService)
+	groupController := controllers.NewGroupController(groupService)
+	eventController := controllers.NewEventController(eventService)
+	challengeController := controllers.NewChallengeController(challengeService)
+	privacyController := controllers.NewPrivacyController(privacyService)
+	blockController := controllers.NewBlockController(blockService)
+	shareController := controllers.NewShareController(shareService)
+	moderationController := controllers.NewModerationController(moderationService)
+	streamController := controllers.NewStreamController(services.GetHub(), services.GetStreamTicketStore(), sessionService)
 	manifestController := controllers.NewManifestController(updateLogService)
 	updateLogController := controllers.NewUpdateLogController(updateLogService)
 	errorLogController := controllers.NewErrorLogController(logsDB)
@@ -58,7 +96,9 @@ func SetupRoutes(r *gin.Engine, db *gorm.DB, logsDB *gorm.DB) {
 		{
 			auth.POST("/register", authController.Register)
 			auth.POST("/login", authController.Login)
-			auth.POST("/logout", authController.Logout)
+			auth.POST("/refresh", authController.Refresh)
+			auth.POST("/logout", middleware.AuthMiddleware(db), authController.Logout)
+			auth.POST("/logout-all", middleware.AuthMiddleware(db), authController.LogoutAll)
 			auth.POST("/send-reset-code", authController.SendCode)
 			auth.POST("/verify-code", authController.VerifyCode)
 			auth.POST("/reset-password", authController.ResetPassword)
@@ -66,17 +106,37 @@ func SetupRoutes(r *gin.Engine, db *gorm.DB, logsDB *gorm.DB) {
 
 		// Manifest 相关路由 - 公开访问
 		api.GET("/manifest", manifestController.GetManifest)
-		api.POST("/manifest", middleware.AdminAuth(), manifestController.UpdateManifest)
+		api.POST("/manifest", middleware.AuthMiddleware(db), middleware.AdminAuth(db), manifestController.UpdateManifest)
+
+		// 实时推送，EventSource 无法设置请求头，支持通过票据认证；票据绑定登录会话，连接期间及断开后 60 秒内可重复使用以便自动重连
+		api.GET("/stream", middleware.StreamAuthMiddleware(db), streamController.Stream)
+
+		// 运动记录分享 - 公开访问，凭分享 token 查看
+		api.GET("/share/:token", shareController.GetSharedRecord)
+		api.GET("/share/:token/card", shareController.GetSharedRecordCard)
 
 		// 需要认证的路由
 		authorized := api.Group("")
-		authorized.Use(middleware.AuthMiddleware())
+		authorized.Use(middleware.AuthMiddleware(db))
 		{
 			// 用户相关路由
 			users := authorized.Group("/users")
 			{
 				users.GET("/profile", userController.GetProfile)
-				users.PUT("/profile", userController.UpdateProfile)
+				users.PUT("/profile", middleware.ActiveUserRequired(db), userController.UpdateProfile)
+				users.GET("/privacy", privacyController.GetSettings)
+				users.PUT("/privacy", privacyController.UpdateSettings)
+				users.GET("/blocks", blockController.GetBlocks)
+				users.GET("/mutes", blockController.GetMutes)
+				users.GET("/:id", followController.GetUserProfile)
+				users.PUT("/:id/follow", middleware.ActiveUserRequired(db), followController.Follow)
+				users.DELETE("/:id/follow", followController.Unfollow)
+				users.GET("/:id/followers", followController.GetFollowers)
+				users.GET("/:id/following", followController.GetFollowing)
+				users.PUT("/:id/block", blockController.Block)
+				users.DELETE("/:id/block", blockController.Unblock)
+				users.PUT("/:id/mute", blockController.Mute)
+				users.DELETE("/:id/mute", blockController.Unmute)
 			}
 
 			// 记录相关路由
@@ -87,6 +147,13 @@ func SetupRoutes(r *gin.Engine, db *gorm.DB, logsDB *gorm.DB) {
 				records.PUT("/:id", recordController.UpdateRecord)
 				records.DELETE("/:id", recordController.DeleteRecord)
 				records.GET("/stats", recordController.GetStats)
+				records.GET("/legacy/:source/:id", recordController.ResolveLegacyRecord)
+				records.POST("/:id/template", templateController.CreateTemplateFromRecord)
+				records.POST("/from-template/:id", templateController.CreateRecordFromTemplate)
+				records.POST("/:id/share", middleware.ActiveUserRequired(db), shareController.CreateShare)
+				records.GET("/:id/shares", shareController.GetShares)
+				records.DELETE("/:id/shares/:share_id", shareController.RevokeShare)
+				records.GET("/:id/card", shareController.GetRecordCard)
 			}
 
 			// 运动类型相关路由
@@ -98,6 +165,139 @@ func SetupRoutes(r *gin.Engine, db *gorm.DB, logsDB *gorm.DB) {
 				sportTypes.DELETE("/:id", sportTypeController.DeleteSportType)
 			}
 
+			// 训练模板路由
+			templates := authorized.Group("/templates")
+			{
+				templates.GET("", templateController.GetTemplates)
+				templates.POST("", templateController.CreateTemplate)
+				templates.PUT("/:id", templateController.UpdateTemplate)
+				templates.DELETE("/:id", templateController.DeleteTemplate)
+			}
+
+			// 运动装备路由
+			gear := authorized.Group("/gear")
+			{
+				gear.GET("", gearController.GetGear)
+				gear.POST("", gearController.CreateGear)
+				gear.GET("/:id", gearController.GetGearByID)
+				gear.PUT("/:id", gearController.UpdateGear)
+				gear.DELETE("/:id", gearController.DeleteGear)
+				gear.GET("/:id/stats", gearController.GetGearStats)
+			}
+
+			// 力量训练动作库路由
+			strengthExercises := authorized.Group("/strength-exercises")
+			{
+				strengthExercises.GET("", strengthController.GetExercises)
+				strengthExercises.POST("", strengthController.CreateExercise)
+				strengthExercises.DELETE("/:id", strengthController.DeleteExercise)
+				strengthExercises.GET("/:id/progression", strengthController.GetProgression)
+			}
+
+			// 社区路由
+			community := authorized.Group("/community")
+			community.Use(middleware.ActiveUserRequired(db))
+			{
+				community.GET("/check-ins", communityController.GetFeed)
+				community.GET("/following", communityController.GetFollowingFeed)
+				community.POST("/check-ins", communityController.CreateCheckIn)
+				community.GET("/check-ins/:id", communityController.GetCheckIn)
+				community.DELETE("/check-ins/:id", communityController.DeleteCheckIn)
+				community.GET("/check-ins/:id/comments", commentController.ListComments)
+				community.POST("/check-ins/:id/comments", commentController.CreateComment)
+				community.GET("/check-ins/:id/likes", likeController.ListLikes)
+				community.PUT("/check-ins/:id/like", likeController.Like)
+				community.DELETE("/check-ins/:id/like", likeController.Unlike)
+				community.PUT("/comments/:id", commentController.UpdateComment)
+				community.DELETE("/comments/:id", commentController.DeleteComment)
+				community.GET("/users/:id/check-ins", communityController.GetUserFeed)
+				community.GET("/tags/trending", hashtagController.GetTrendingTags)
+				community.GET("/tags/:name/check-ins", hashtagController.GetTagFeed)
+			}
+
+			authorized.POST("/stream/ticket", streamController.IssueTicket)
+
+			// 搜索路由
+			authorized.GET("/search", searchController.Search)
+
+			// 通知路由
+			notifications := authorized.Group("/notifications")
+			{
+				notifications.GET("", notificationController.GetNotifications)
+				notifications.GET("/unread-count", notificationController.GetUnreadCount)
+				notifications.PUT("/read-all", notificationController.MarkAllRead)
+				notifications.PUT("/:id/read", notificationController.MarkRead)
+			}
+
+			// 私信路由
+			conversations := authorized.Group("/conversations")
+			conversations.Use(middleware.ActiveUserRequired(db))
+			{
+				conversations.GET("", messageController.GetConversations)
+				conversations.POST("", messageController.OpenConversation)
+				conversations.GET("/unread-count", messageController.GetUnreadCount)
+				conversations.GET("/settings", messageController.GetSettings)
+				conversations.PUT("/settings", messageController.UpdateSettings)
+				conversations.GET("/:id", messageController.GetConversation)
+				conversations.GET("/:id/messages", messageController.GetMessages)
+				conversations.POST("/:id/messages", messageController.SendMessage)
+				conversations.PUT("/:id/read", messageController.MarkRead)
+			}
+
+			// 群组路由
+			groups := authorized.Group("/groups")
+			groups.Use(middleware.ActiveUserRequired(db))
+			{
+				groups.GET("", groupController.GetGroups)
+				groups.POST("", groupController.CreateGroup)
+				groups.GET("/mine", groupController.GetMyGroups)
+				groups.POST("/join", groupController.JoinByInviteCode)
+				groups.GET("/:id", groupController.GetGroup)
+				groups.PUT("/:id", groupController.UpdateGroup)
+				groups.DELETE("/:id", groupController.DeleteGroup)
+				groups.POST("/:id/join", groupController.JoinGroup)
+				groups.POST("/:id/leave", groupController.LeaveGroup)
+				groups.POST("/:id/invite-code", groupController.ResetInviteCode)
+				groups.GET("/:id/members", groupController.GetMembers)
+				groups.PUT("/:id/members/:user_id/role", groupController.UpdateMemberRole)
+				groups.DELETE("/:id/members/:user_id", groupController.RemoveMember)
+				groups.GET("/:id/requests", groupController.GetJoinRequests)
+				groups.POST("/:id/requests/:request_id/approve", groupController.ApproveJoinRequest)
+				groups.POST("/:id/requests/:request_id/reject", groupController.RejectJoinRequest)
+				groups.GET("/:id/feed", groupController.GetGroupFeed)
+				groups.GET("/:id/stats", groupController.GetGroupStats)
+			}
+
+			// 活动路由
+			events := authorized.Group("/events")
+			events.Use(middleware.ActiveUserRequired(db))
+			{
+				events.GET("", eventController.GetEvents)
+				events.POST("", eventController.CreateEvent)
+				events.GET("/:id", eventController.GetEvent)
+				events.PUT("/:id", eventController.UpdateEvent)
+				events.POST("/:id/cancel", eventController.CancelEvent)
+				events.PUT("/:id/rsvp", eventController.RSVP)
+				events.GET("/:id/attendees", eventController.GetAttendees)
+				events.PUT("/:id/attendance", eventController.MarkAttendance)
+				events.PUT("/:id/record", eventController.LinkRecord)
+				events.DELETE("/:id/record", eventController.UnlinkRecord)
+			}
+
+			// 挑战路由
+			challenges := authorized.Group("/challenges")
+			challenges.Use(middleware.ActiveUserRequired(db))
+			{
+				challenges.GET("", challengeController.GetChallenges)
+				challenges.POST("", challengeController.CreateChallenge)
+				challenges.GET("/:id", challengeController.GetChallenge)
+				challenges.DELETE("/:id", challengeController.DeleteChallenge)
+				challenges.POST("/:id/join", challengeController.JoinChallenge)
+				challenges.DELETE("/:id/join", challengeController.LeaveChallenge)
+				challenges.GET("/:id/leaderboard", challengeController.GetLeaderboard)
+				challenges.GET("/:id/teams", challengeController.GetTeamLeaderboard)
+			}
+
 			// 图片上传路由
 			upload := authorized.Group("/upload")
 			{
@@ -105,9 +305,25 @@ func SetupRoutes(r *gin.Engine, db *gorm.DB, logsDB *gorm.DB) {
 				upload.POST("/image", uploadController.UploadImage)
 			}
 
+			// 举报
+			authorized.POST("/reports", middleware.ActiveUserRequired(db), moderationController.CreateReport)
+
+			// 管理员审核路由
+			admin := authorized.Group("/admin")
+			admin.Use(middleware.AdminAuth(db))
+			{
+				admin.GET("/reports", moderationController.GetReports)
+				admin.POST("/reports/:id/:action", moderationController.HandleReport)
+				admin.POST("/targets/:type/:id/restore", moderationController.RestoreTarget)
+				admin.GET("/reviews", moderationController.GetReviews)
+				admin.POST("/reviews/:id/approve", moderationController.ApproveReview)
+				admin.POST("/reviews/:id/reject", moderationController.RejectReview)
+				admin.GET("/actions", moderationController.GetActions)
+			}
+
 			// 更新日志相关路由 - 需要管理员权限
-			api.GET("/update-logs", middleware.AdminAuth(), updateLogController.GetUpdateLogs)
-			api.GET("/update-stats", middleware.AdminAuth(), updateLogController.GetUpdateStats)
+			authorized.GET("/update-logs", middleware.AdminAuth(db), updateLogController.GetUpdateLogs)
+			authorized.GET("/update-stats", middleware.AdminAuth(db), updateLogController.GetUpdateStats)
 		}
 	}
 
@@ -120,10 +336,15 @@ func SetupRoutes(r *gin.Engine, db *gorm.DB, logsDB *gorm.DB) {
 		})
 	})
 
+	// 运动记录分享页，供微信等渠道抓取 Open Graph 预览
+	r.GET("/share/:token", shareController.RenderSharePage)
+
+	// 访问令牌的校验公钥，供其他服务校验令牌
+	r.GET("/.well-known/jwks.json", authController.JWKS)
+
 	// Live Update manifest
 	r.GET("/api/manifest.json", manifestHandler)
 
 	// TODO: 添加其他功能模块的路由
 	// SetupRecordRoutes(api, db)
-	// SetupCommunityRoutes(api, db)
 }
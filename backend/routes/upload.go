@@ -5,14 +5,15 @@ import (
 	"sports-app/backend/middleware"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func SetupUploadRoutes(router *gin.Engine) {
+func SetupUploadRoutes(router *gin.Engine, db *gorm.DB) {
 	uploadController := controllers.NewUploadController()
 
 	// 图片上传路由组
 	uploadGroup := router.Group("/api/upload")
-	uploadGroup.Use(middleware.AuthMiddleware())
+	uploadGroup.Use(middleware.AuthMiddleware(db))
 	{
 		uploadGroup.POST("/image", uploadController.UploadImage)
 	}
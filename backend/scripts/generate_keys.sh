@@ -1,16 +1,38 @@
 #!/bin/bash
+# 生成访问令牌的签名密钥，密钥已加入 .gitignore，不要提交到代码仓库
+# 用法: ./generate_keys.sh [rsa|ed25519]，默认 rsa
+set -e
+
+KEY_DIR="$(cd "$(dirname "$0")/.." && pwd)/config/keys"
+ALGORITHM="${1:-rsa}"
 
 # 创建密钥目录
-mkdir -p ../config/keys
+mkdir -p "$KEY_DIR"
+
+if [ -e "$KEY_DIR/private.pem" ]; then
+    echo "$KEY_DIR/private.pem 已存在，轮换密钥时请先备份旧公钥" >&2
+    exit 1
+fi
 
 # 生成私钥
-openssl genrsa -out ../config/keys/private.pem 2048
+case "$ALGORITHM" in
+    rsa)
+        openssl genpkey -algorithm RSA -pkeyopt rsa_keygen_bits:2048 -out "$KEY_DIR/private.pem"
+        ;;
+    ed25519)
+        openssl genpkey -algorithm ed25519 -out "$KEY_DIR/private.pem"
+        ;;
+    *)
+        echo "不支持的算法 $ALGORITHM，只支持 rsa 和 ed25519" >&2
+        exit 1
+        ;;
+esac
 
 # 从私钥生成公钥
-openssl rsa -in ../config/keys/private.pem -pubout -out ../config/keys/public.pem
+openssl pkey -in "$KEY_DIR/private.pem" -pubout -out "$KEY_DIR/public.pem"
 
 # 设置适当的文件权限
-chmod 600 ../config/keys/private.pem
-chmod 644 ../config/keys/public.pem
+chmod 600 "$KEY_DIR/private.pem"
+chmod 644 "$KEY_DIR/public.pem"
 
-echo "RSA 密钥对已生成" 
\ No newline at end of file
+echo "$ALGORITHM 密钥对已生成到 $KEY_DIR"
@@ -72,6 +72,8 @@ CREATE TABLE IF NOT EXISTS likes (
     user_id BIGINT UNSIGNED NOT NULL,
     check_in_id BIGINT UNSIGNED NOT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
     UNIQUE KEY uk_user_check_in (user_id, check_in_id),
     FOREIGN KEY (user_id) REFERENCES users(id),
     FOREIGN KEY (check_in_id) REFERENCES check_ins(id)
@@ -9,26 +9,19 @@ import (
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
-
-	"github.com/golang-jwt/jwt/v5"
 )
 
-var jwtKey = []byte("your-secret-key")
-
-type Claims struct {
-    UserID int64 `json:"user_id"`
-    jwt.RegisteredClaims
-}
-
 type AuthService struct {
     db                  *gorm.DB
     verificationService *VerificationService
+    sessions            *SessionService
 }
 
 func NewAuthService(db *gorm.DB, verificationService *VerificationService) *AuthService {
     return &AuthService{
         db:                  db,
         verificationService: verificationService,
+        sessions:            NewSessionService(db),
     }
 }
 
@@ -41,6 +34,15 @@ func (s *AuthService) Register(user *models.User) error {
     if count > 0 {
         return errors.New("用户名已存在")
     }
+
+    // 用户名不支持打码，mask 策略按拒绝处理
+    moderation, err := GetModerator().Check(ModerationFieldUsername, user.Username)
+    if err != nil {
+        return err
+    }
+    if moderation.Text != user.Username {
+        return fmt.Errorf("%w，请修改后重试", ErrSensitiveContent)
+    }
     
     // 加密密码
     hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
@@ -49,8 +51,16 @@ func (s *AuthService) Register(user *models.User) error {
     }
     user.Password = string(hashedPassword)
     
-    // 创建用户
-    return s.db.Create(user).Error
+    // 创建用户，命中敏感词且策略为人工审核时同时加入审核队列
+    return s.db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Create(user).Error; err != nil {
+            return err
+        }
+        if !moderation.NeedsReview {
+            return nil
+        }
+        return queueReview(tx, models.ModerationTargetUser, uint64(user.ID), user.ID, user.Username, moderation.Matched)
+    })
 }
 
 func (s *AuthService) Login(username, password string) (*models.User, error) {
@@ -72,38 +82,6 @@ func (s *AuthService) Login(username, password string) (*models.User, error) {
 	return &user, nil
 }
 
-func GenerateToken(userID int64) (string, error) {
-    expirationTime := time.Now().Add(24 * time.Hour)
-    claims := &Claims{
-        UserID: userID,
-        RegisteredClaims: jwt.RegisteredClaims{
-            ExpiresAt: jwt.NewNumericDate(expirationTime),
-            IssuedAt:  jwt.NewNumericDate(time.Now()),
-            NotBefore: jwt.NewNumericDate(time.Now()),
-        },
-    }
-    
-    token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-    return token.SignedString(jwtKey)
-}
-
-func ValidateToken(tokenString string) (*Claims, error) {
-    claims := &Claims{}
-    token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-        return jwtKey, nil
-    })
-    
-    if err != nil {
-        return nil, err
-    }
-    
-    if !token.Valid {
-        return nil, errors.New("无效的token")
-    }
-    
-    return claims, nil
-}
-
 func (s *AuthService) VerifyCode(email, code string) bool {
 	if s.verificationService == nil {
 		return false
@@ -128,6 +106,11 @@ func (s *AuthService) ResetPassword(email, newPassword string) (string, error) {
 		return "", err
 	}
 
+	// 重置密码后所有设备需要重新登录
+	if _, err := s.sessions.RevokeAll(user.ID, models.SessionRevokePasswordReset); err != nil {
+		return "", err
+	}
+
 	return user.Username, nil
 }
 
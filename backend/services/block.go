@@ -0,0 +1,147 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sports-app/backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidBlock 屏蔽或静音参数校验失败
+var ErrInvalidBlock = errors.New("无效的屏蔽操作")
+
+// blockedUserIDs 与 userID 存在屏蔽关系（任一方向）的用户ID子查询
+func blockedUserIDs(db *gorm.DB, userID int64) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).
+		Raw("SELECT blocked_id FROM user_blocks WHERE blocker_id = ? UNION SELECT blocker_id FROM user_blocks WHERE blocked_id = ?",
+			userID, userID)
+}
+
+// mutedUserIDs userID 静音的用户ID子查询
+func mutedUserIDs(db *gorm.DB, userID int64) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&models.UserMute{}).
+		Select("muted_id").Where("muter_id = ?", userID)
+}
+
+// excludeBlocked 排除 column 对应的用户与 viewer 存在屏蔽关系的数据
+func excludeBlocked(db *gorm.DB, column string, viewerID int64) *gorm.DB {
+	return db.Where(column+" NOT IN (?)", blockedUserIDs(db, viewerID))
+}
+
+// excludeMuted 排除 column 对应的用户被 viewer 静音的数据，用于动态列表
+func excludeMuted(db *gorm.DB, column string, viewerID int64) *gorm.DB {
+	return db.Where(column+" NOT IN (?)", mutedUserIDs(db, viewerID))
+}
+
+// isBlocked 判断两个用户之间是否存在屏蔽关系（任一方向）
+func isBlocked(db *gorm.DB, userA, userB int64) (bool, error) {
+	var count int64
+	if err := db.Model(&models.UserBlock{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", userA, userB, userB, userA).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// BlockService 屏蔽与静音服务
+type BlockService struct {
+	db *gorm.DB
+}
+
+// NewBlockService 创建屏蔽服务实例
+func NewBlockService(db *gorm.DB) *BlockService {
+	return &BlockService{db: db}
+}
+
+// checkTarget 校验屏蔽或静音的对象
+func (s *BlockService) checkTarget(userID, targetID int64) error {
+	if userID == targetID {
+		return fmt.Errorf("%w: 不能屏蔽或静音自己", ErrInvalidBlock)
+	}
+	var target models.PublicUser
+	return s.db.Select("id").First(&target, targetID).Error
+}
+
+// Block 屏蔽用户并解除双方的关注关系，重复屏蔽不报错
+func (s *BlockService) Block(userID, targetID int64) error {
+	if err := s.checkTarget(userID, targetID); err != nil {
+		return err
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// 依赖唯一索引 uk_blocker_blocked 去重
+		if err := tx.Omit("Blocked").Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.UserBlock{BlockerID: userID, BlockedID: targetID}).Error; err != nil {
+			return err
+		}
+		return tx.Where("(follower_id = ? AND followee_id = ?) OR (follower_id = ? AND followee_id = ?)",
+			userID, targetID, targetID, userID).
+			Delete(&models.Follow{}).Error
+	})
+}
+
+// Unblock 取消屏蔽，未屏蔽时直接返回；已解除的关注关系不会恢复
+func (s *BlockService) Unblock(userID, targetID int64) error {
+	return s.db.Where("blocker_id = ? AND blocked_id = ?", userID, targetID).
+		Delete(&models.UserBlock{}).Error
+}
+
+// Mute 静音用户，重复静音不报错
+func (s *BlockService) Mute(userID, targetID int64) error {
+	if err := s.checkTarget(userID, targetID); err != nil {
+		return err
+	}
+	// 依赖唯一索引 uk_muter_muted 去重
+	return s.db.Omit("Muted").Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.UserMute{MuterID: userID, MutedID: targetID}).Error
+}
+
+// Unmute 取消静音，未静音时直接返回
+func (s *BlockService) Unmute(userID, targetID int64) error {
+	return s.db.Where("muter_id = ? AND muted_id = ?", userID, targetID).
+		Delete(&models.UserMute{}).Error
+}
+
+// GetBlocks 分页获取屏蔽列表，按屏蔽时间倒序
+func (s *BlockService) GetBlocks(userID int64, page, pageSize int) ([]models.UserBlock, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	query := s.db.Model(&models.UserBlock{}).Where("blocker_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	blocks := make([]models.UserBlock, 0)
+	if err := query.Preload("Blocked").
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&blocks).Error; err != nil {
+		return nil, 0, err
+	}
+	return blocks, total, nil
+}
+
+// GetMutes 分页获取静音列表，按静音时间倒序
+func (s *BlockService) GetMutes(userID int64, page, pageSize int) ([]models.UserMute, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	query := s.db.Model(&models.UserMute{}).Where("muter_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	mutes := make([]models.UserMute, 0)
+	if err := query.Preload("Muted").
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&mutes).Error; err != nil {
+		return nil, 0, err
+	}
+	return mutes, total, nil
+}
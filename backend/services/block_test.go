@@ -0,0 +1,361 @@
+package services
+
+import (
+	"errors"
+	"sports-app/backend/models"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// blockFixture 屏蔽测试的用户：alice 屏蔽了 bob，carol 与双方都没有屏蔽关系
+type blockFixture struct {
+	db                *gorm.DB
+	alice, bob, carol int64
+	sportTypeID       uint64
+}
+
+// newBlockFixture 创建测试用户和运动类型，屏蔽关系由各测试按需建立
+func newBlockFixture(t *testing.T) *blockFixture {
+	t.Helper()
+	db := newTestDB(t)
+	return &blockFixture{
+		db:          db,
+		alice:       createTestUser(t, db, "alice"),
+		bob:         createTestUser(t, db, "bob"),
+		carol:       createTestUser(t, db, "carol"),
+		sportTypeID: createTestSportType(t, db),
+	}
+}
+
+// block 由 alice 屏蔽 bob
+func (f *blockFixture) block(t *testing.T) {
+	t.Helper()
+	if err := NewBlockService(f.db).Block(f.alice, f.bob); err != nil {
+		t.Fatalf("屏蔽失败: %v", err)
+	}
+}
+
+// blockDirections 屏蔽关系的两个方向，分别以屏蔽者和被屏蔽者作为当前用户
+func (f *blockFixture) blockDirections() []struct {
+	name         string
+	viewer, peer int64
+} {
+	return []struct {
+		name         string
+		viewer, peer int64
+	}{
+		{"屏蔽者", f.alice, f.bob},
+		{"被屏蔽者", f.bob, f.alice},
+	}
+}
+
+func TestBlockHidesFeeds(t *testing.T) {
+	f := newBlockFixture(t)
+	follows := NewFollowService(f.db)
+	for _, pair := range [][2]int64{{f.alice, f.bob}, {f.bob, f.alice}} {
+		if err := follows.Follow(pair[0], pair[1]); err != nil {
+			t.Fatalf("关注失败: %v", err)
+		}
+	}
+	posts := map[int64]uint64{
+		f.alice: createTestCheckIn(t, f.db, f.alice, f.sportTypeID, models.VisibilityPublic),
+		f.bob:   createTestCheckIn(t, f.db, f.bob, f.sportTypeID, models.VisibilityPublic),
+	}
+	f.block(t)
+
+	// 屏蔽会解除关注关系，这里补回关注数据，验证关系数据不一致时动态仍然排除对方
+	for _, pair := range [][2]int64{{f.alice, f.bob}, {f.bob, f.alice}} {
+		if err := f.db.Create(&models.Follow{FollowerID: pair[0], FolloweeID: pair[1]}).Error; err != nil {
+			t.Fatalf("补回关注失败: %v", err)
+		}
+	}
+
+	community := NewCommunityService(f.db)
+	for _, d := range f.blockDirections() {
+		t.Run(d.name, func(t *testing.T) {
+			feed, _, err := community.GetFeed(d.viewer, 1, 20)
+			if err != nil {
+				t.Fatalf("获取全站动态失败: %v", err)
+			}
+			if checkInIDs(feed)[posts[d.peer]] {
+				t.Error("全站动态包含存在屏蔽关系的用户的打卡")
+			}
+			if !checkInIDs(feed)[posts[d.viewer]] {
+				t.Error("全站动态缺少本人的打卡")
+			}
+
+			following, _, err := community.GetFollowingFeed(d.viewer, "", 20)
+			if err != nil {
+				t.Fatalf("获取关注动态失败: %v", err)
+			}
+			if checkInIDs(following)[posts[d.peer]] {
+				t.Error("关注动态包含存在屏蔽关系的用户的打卡")
+			}
+
+			userFeed, total, err := community.GetUserFeed(d.viewer, d.peer, 1, 20)
+			if err != nil {
+				t.Fatalf("获取用户动态失败: %v", err)
+			}
+			if total != 0 || len(userFeed) != 0 {
+				t.Errorf("用户动态应为空，实际返回 %d 条", total)
+			}
+
+			if _, err := community.GetCheckIn(d.viewer, int64(posts[d.peer])); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("查看对方打卡应返回记录不存在，实际为 %v", err)
+			}
+		})
+	}
+}
+
+func TestBlockRemovesFollowsAndForbidsFollowing(t *testing.T) {
+	f := newBlockFixture(t)
+	follows := NewFollowService(f.db)
+	for _, pair := range [][2]int64{{f.alice, f.bob}, {f.bob, f.alice}, {f.alice, f.carol}, {f.bob, f.carol}} {
+		if err := follows.Follow(pair[0], pair[1]); err != nil {
+			t.Fatalf("关注失败: %v", err)
+		}
+	}
+	f.block(t)
+
+	for _, d := range f.blockDirections() {
+		t.Run(d.name, func(t *testing.T) {
+			following, err := follows.IsFollowing(d.viewer, d.peer)
+			if err != nil {
+				t.Fatalf("查询关注关系失败: %v", err)
+			}
+			if following {
+				t.Error("屏蔽后关注关系未解除")
+			}
+
+			if err := follows.Follow(d.viewer, d.peer); !errors.Is(err, ErrForbidden) {
+				t.Errorf("关注对方应返回无权操作，实际为 %v", err)
+			}
+
+			// carol 的粉丝列表中不出现存在屏蔽关系的用户
+			followers, total, err := follows.GetFollowers(d.viewer, f.carol, 1, 20)
+			if err != nil {
+				t.Fatalf("获取粉丝列表失败: %v", err)
+			}
+			if total != 1 {
+				t.Errorf("粉丝数应为1，实际为 %d", total)
+			}
+			for _, follow := range followers {
+				if follow.FollowerID == d.peer {
+					t.Error("粉丝列表包含存在屏蔽关系的用户")
+				}
+			}
+		})
+	}
+
+	// 被屏蔽者查看屏蔽者的关注列表时视为用户不存在
+	if _, _, err := follows.GetFollowing(f.bob, f.alice, 1, 20); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("被屏蔽者查看关注列表应返回记录不存在，实际为 %v", err)
+	}
+}
+
+func TestBlockForbidsComments(t *testing.T) {
+	f := newBlockFixture(t)
+	comments := NewCommentService(f.db)
+	posts := map[int64]uint64{
+		f.alice: createTestCheckIn(t, f.db, f.alice, f.sportTypeID, models.VisibilityPublic),
+		f.bob:   createTestCheckIn(t, f.db, f.bob, f.sportTypeID, models.VisibilityPublic),
+	}
+	carolPost := createTestCheckIn(t, f.db, f.carol, f.sportTypeID, models.VisibilityPublic)
+	commentIDs := make(map[int64]uint64)
+	for _, userID := range []int64{f.alice, f.bob} {
+		comment, err := comments.CreateComment(userID, int64(carolPost), "加油", nil)
+		if err != nil {
+			t.Fatalf("发表评论失败: %v", err)
+		}
+		commentIDs[userID] = comment.ID
+	}
+	f.block(t)
+
+	for _, d := range f.blockDirections() {
+		t.Run(d.name, func(t *testing.T) {
+			list, total, err := comments.ListComments(d.viewer, int64(carolPost), 1, 20, true)
+			if err != nil {
+				t.Fatalf("获取评论失败: %v", err)
+			}
+			if total != 1 {
+				t.Errorf("评论数应为1，实际为 %d", total)
+			}
+			for _, comment := range list {
+				if int64(comment.UserID) == d.peer {
+					t.Error("评论列表包含存在屏蔽关系的用户的评论")
+				}
+			}
+
+			parentID := commentIDs[d.peer]
+			if _, err := comments.CreateComment(d.viewer, int64(carolPost), "回复", &parentID); !errors.Is(err, ErrForbidden) {
+				t.Errorf("回复对方的评论应返回无权操作，实际为 %v", err)
+			}
+			if _, err := comments.CreateComment(d.viewer, int64(posts[d.peer]), "评论", nil); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("评论对方的打卡应返回记录不存在，实际为 %v", err)
+			}
+			if _, _, err := comments.ListComments(d.viewer, int64(posts[d.peer]), 1, 20, false); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("查看对方打卡的评论应返回记录不存在，实际为 %v", err)
+			}
+		})
+	}
+}
+
+func TestBlockForbidsLikes(t *testing.T) {
+	f := newBlockFixture(t)
+	likes := NewLikeService(f.db)
+	posts := map[int64]uint64{
+		f.alice: createTestCheckIn(t, f.db, f.alice, f.sportTypeID, models.VisibilityPublic),
+		f.bob:   createTestCheckIn(t, f.db, f.bob, f.sportTypeID, models.VisibilityPublic),
+	}
+	carolPost := createTestCheckIn(t, f.db, f.carol, f.sportTypeID, models.VisibilityPublic)
+	for _, userID := range []int64{f.alice, f.bob} {
+		if _, err := likes.Like(userID, int64(carolPost)); err != nil {
+			t.Fatalf("点赞失败: %v", err)
+		}
+	}
+	f.block(t)
+
+	for _, d := range f.blockDirections() {
+		t.Run(d.name, func(t *testing.T) {
+			if _, err := likes.Like(d.viewer, int64(posts[d.peer])); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("点赞对方的打卡应返回记录不存在，实际为 %v", err)
+			}
+
+			list, total, err := likes.ListLikes(d.viewer, int64(carolPost), 1, 20)
+			if err != nil {
+				t.Fatalf("获取点赞列表失败: %v", err)
+			}
+			if total != 1 {
+				t.Errorf("点赞数应为1，实际为 %d", total)
+			}
+			for _, like := range list {
+				if int64(like.UserID) == d.peer {
+					t.Error("点赞列表包含存在屏蔽关系的用户")
+				}
+			}
+		})
+	}
+}
+
+func TestBlockForbidsMessages(t *testing.T) {
+	f := newBlockFixture(t)
+	messages := NewMessageService(f.db)
+	member, err := messages.OpenConversation(f.alice, f.bob)
+	if err != nil {
+		t.Fatalf("创建会话失败: %v", err)
+	}
+	for _, userID := range []int64{f.alice, f.bob} {
+		if _, err := messages.SendMessage(userID, member.ConversationID, "你好"); err != nil {
+			t.Fatalf("发送私信失败: %v", err)
+		}
+	}
+	f.block(t)
+
+	for _, d := range f.blockDirections() {
+		t.Run(d.name, func(t *testing.T) {
+			if _, err := messages.OpenConversation(d.viewer, d.peer); !errors.Is(err, ErrForbidden) {
+				t.Errorf("打开会话应返回无权操作，实际为 %v", err)
+			}
+			// 已有会话中双方互发过私信，屏蔽关系仍然优先于私信权限
+			if _, err := messages.SendMessage(d.viewer, member.ConversationID, "还在吗"); !errors.Is(err, ErrForbidden) {
+				t.Errorf("发送私信应返回无权操作，实际为 %v", err)
+			}
+		})
+	}
+}
+
+func TestUnblockRestoresVisibility(t *testing.T) {
+	f := newBlockFixture(t)
+	post := createTestCheckIn(t, f.db, f.bob, f.sportTypeID, models.VisibilityPublic)
+	f.block(t)
+	if err := NewBlockService(f.db).Unblock(f.alice, f.bob); err != nil {
+		t.Fatalf("取消屏蔽失败: %v", err)
+	}
+
+	feed, _, err := NewCommunityService(f.db).GetFeed(f.alice, 1, 20)
+	if err != nil {
+		t.Fatalf("获取全站动态失败: %v", err)
+	}
+	if !checkInIDs(feed)[post] {
+		t.Error("取消屏蔽后全站动态仍然缺少对方的打卡")
+	}
+	if err := NewFollowService(f.db).Follow(f.alice, f.bob); err != nil {
+		t.Errorf("取消屏蔽后应能关注对方，实际为 %v", err)
+	}
+}
+
+func TestMuteOnlyFiltersMutersFeeds(t *testing.T) {
+	f := newBlockFixture(t)
+	follows := NewFollowService(f.db)
+	for _, pair := range [][2]int64{{f.alice, f.bob}, {f.bob, f.alice}, {f.carol, f.bob}} {
+		if err := follows.Follow(pair[0], pair[1]); err != nil {
+			t.Fatalf("关注失败: %v", err)
+		}
+	}
+	alicePost := createTestCheckIn(t, f.db, f.alice, f.sportTypeID, models.VisibilityPublic)
+	bobPost := createTestCheckIn(t, f.db, f.bob, f.sportTypeID, models.VisibilityFollowers)
+	bobPublicPost := createTestCheckIn(t, f.db, f.bob, f.sportTypeID, models.VisibilityPublic)
+	if err := NewBlockService(f.db).Mute(f.alice, f.bob); err != nil {
+		t.Fatalf("静音失败: %v", err)
+	}
+
+	community := NewCommunityService(f.db)
+	feed, _, err := community.GetFeed(f.alice, 1, 20)
+	if err != nil {
+		t.Fatalf("获取全站动态失败: %v", err)
+	}
+	if checkInIDs(feed)[bobPublicPost] {
+		t.Error("静音者的全站动态包含被静音用户的打卡")
+	}
+	following, _, err := community.GetFollowingFeed(f.alice, "", 20)
+	if err != nil {
+		t.Fatalf("获取关注动态失败: %v", err)
+	}
+	if ids := checkInIDs(following); ids[bobPost] || ids[bobPublicPost] {
+		t.Error("静音者的关注动态包含被静音用户的打卡")
+	}
+
+	// 静音只影响动态列表，主动访问对方主页和打卡不受影响
+	userFeed, total, err := community.GetUserFeed(f.alice, f.bob, 1, 20)
+	if err != nil {
+		t.Fatalf("获取用户动态失败: %v", err)
+	}
+	if total != 2 || !checkInIDs(userFeed)[bobPost] {
+		t.Errorf("静音者访问对方主页应看到2条打卡，实际为 %d 条", total)
+	}
+	if _, err := NewLikeService(f.db).Like(f.alice, int64(bobPublicPost)); err != nil {
+		t.Errorf("静音后应能点赞对方的打卡，实际为 %v", err)
+	}
+
+	// 被静音者和其他用户的动态不受影响
+	bobFeed, _, err := community.GetFollowingFeed(f.bob, "", 20)
+	if err != nil {
+		t.Fatalf("获取关注动态失败: %v", err)
+	}
+	if !checkInIDs(bobFeed)[alicePost] {
+		t.Error("被静音者的关注动态缺少静音者的打卡")
+	}
+	carolFeed, _, err := community.GetFollowingFeed(f.carol, "", 20)
+	if err != nil {
+		t.Fatalf("获取关注动态失败: %v", err)
+	}
+	if ids := checkInIDs(carolFeed); !ids[bobPost] || !ids[bobPublicPost] {
+		t.Error("其他用户的关注动态缺少被静音用户的打卡")
+	}
+	carolPublic, _, err := community.GetFeed(f.carol, 1, 20)
+	if err != nil {
+		t.Fatalf("获取全站动态失败: %v", err)
+	}
+	if !checkInIDs(carolPublic)[bobPublicPost] {
+		t.Error("其他用户的全站动态缺少被静音用户的打卡")
+	}
+
+	// 静音不影响关注关系和私信
+	if following, err := follows.IsFollowing(f.alice, f.bob); err != nil || !following {
+		t.Errorf("静音不应解除关注关系，实际为 %v, %v", following, err)
+	}
+	if _, err := NewMessageService(f.db).OpenConversation(f.bob, f.alice); err != nil {
+		t.Errorf("被静音者应能给静音者发私信，实际为 %v", err)
+	}
+}
@@ -0,0 +1,685 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sports-app/backend/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 挑战内容限制
+const (
+	maxChallengeTitleLength       = 100
+	maxChallengeDescriptionLength = 2000
+	maxChallengeSportTypes        = 20
+)
+
+// ErrInvalidChallenge 挑战参数校验失败
+var ErrInvalidChallenge = errors.New("无效的挑战")
+
+// challengeMetricExpr 各统计指标对应的聚合表达式
+var challengeMetricExpr = map[string]string{
+	models.ChallengeMetricDuration: "COALESCE(SUM(duration), 0)",
+	models.ChallengeMetricDistance: "COALESCE(SUM(distance), 0)",
+	models.ChallengeMetricCalories: "COALESCE(SUM(calories), 0)",
+	models.ChallengeMetricCount:    "COUNT(*)",
+}
+
+// CreateChallengeInput 创建挑战的参数
+type CreateChallengeInput struct {
+	Title        string    `json:"title" binding:"required"`
+	Description  string    `json:"description"`
+	Type         string    `json:"type"`
+	Metric       string    `json:"metric" binding:"required"`
+	Goal         float64   `json:"goal"`
+	SportTypeIDs []int64   `json:"sport_type_ids"`
+	StartTime    time.Time `json:"start_time" binding:"required"`
+	EndTime      time.Time `json:"end_time" binding:"required"`
+}
+
+// ChallengeFilter 挑战列表筛选条件
+type ChallengeFilter struct {
+	Status string `form:"status"` // upcoming/active/closed，为空时返回未结束的挑战
+	Joined bool   `form:"joined"` // 仅返回自己参与的挑战
+}
+
+// ChallengeService 挑战服务
+type ChallengeService struct {
+	db *gorm.DB
+}
+
+// NewChallengeService 创建挑战服务实例
+func NewChallengeService(db *gorm.DB) *ChallengeService {
+	return &ChallengeService{db: db}
+}
+
+// CreateChallenge 创建挑战，创建者不会自动参与
+func (s *ChallengeService) CreateChallenge(userID int64, input CreateChallengeInput) (*models.Challenge, error) {
+	input.Title = strings.TrimSpace(input.Title)
+	input.Description = strings.TrimSpace(input.Description)
+
+	if input.Title == "" {
+		return nil, fmt.Errorf("%w: 标题不能为空", ErrInvalidChallenge)
+	}
+	if len([]rune(input.Title)) > maxChallengeTitleLength {
+		return nil, fmt.Errorf("%w: 标题不能超过%d个字符", ErrInvalidChallenge, maxChallengeTitleLength)
+	}
+	if len([]rune(input.Description)) > maxChallengeDescriptionLength {
+		return nil, fmt.Errorf("%w: 描述不能超过%d个字符", ErrInvalidChallenge, maxChallengeDescriptionLength)
+	}
+	switch input.Type {
+	case "":
+		input.Type = models.ChallengeTypeIndividual
+	case models.ChallengeTypeIndividual, models.ChallengeTypeTeam:
+	default:
+		return nil, fmt.Errorf("%w: 挑战类型只能是 individual/team", ErrInvalidChallenge)
+	}
+	if _, ok := challengeMetricExpr[input.Metric]; !ok {
+		return nil, fmt.Errorf("%w: 统计指标只能是 duration/distance/calories/count", ErrInvalidChallenge)
+	}
+	if input.Goal < 0 {
+		return nil, fmt.Errorf("%w: 目标值不能为负数", ErrInvalidChallenge)
+	}
+	if !input.EndTime.After(input.StartTime) {
+		return nil, fmt.Errorf("%w: 结束时间必须晚于开始时间", ErrInvalidChallenge)
+	}
+	if !input.EndTime.After(time.Now()) {
+		return nil, fmt.Errorf("%w: 结束时间必须晚于当前时间", ErrInvalidChallenge)
+	}
+
+	sportTypeIDs, err := s.checkSportTypes(input.SportTypeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, text := range []*string{&input.Title, &input.Description} {
+		moderation, err := GetModerator().Check(ModerationFieldGroup, *text)
+		if err != nil {
+			return nil, err
+		}
+		if moderation.NeedsReview {
+			return nil, fmt.Errorf("%w，请修改后重试", ErrSensitiveContent)
+		}
+		*text = moderation.Text
+	}
+
+	challenge := &models.Challenge{
+		CreatorID:    userID,
+		Title:        input.Title,
+		Description:  input.Description,
+		Type:         input.Type,
+		Metric:       input.Metric,
+		Goal:         input.Goal,
+		SportTypeIDs: sportTypeIDs,
+		StartTime:    input.StartTime,
+		EndTime:      input.EndTime,
+	}
+	if err := s.db.Omit("Creator").Create(challenge).Error; err != nil {
+		return nil, err
+	}
+	return s.GetChallenge(userID, challenge.ID)
+}
+
+// checkSportTypes 去重并校验运动类型
+func (s *ChallengeService) checkSportTypes(ids []int64) ([]int64, error) {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) > maxChallengeSportTypes {
+		return nil, fmt.Errorf("%w: 最多选择%d种运动类型", ErrInvalidChallenge, maxChallengeSportTypes)
+	}
+	if len(unique) == 0 {
+		return unique, nil
+	}
+
+	var count int64
+	if err := s.db.Model(&models.SportType{}).Where("id IN ?", unique).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count != int64(len(unique)) {
+		return nil, fmt.Errorf("%w: 运动类型不存在", ErrInvalidChallenge)
+	}
+	return unique, nil
+}
+
+// DeleteChallenge 删除挑战，仅创建者可以在挑战开始前删除
+func (s *ChallengeService) DeleteChallenge(userID int64, id uint64) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var challenge models.Challenge
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&challenge, id).Error; err != nil {
+			return err
+		}
+		if challenge.CreatorID != userID {
+			return fmt.Errorf("%w: 仅创建者可以删除挑战", ErrForbidden)
+		}
+		if challenge.Status != models.ChallengeStatusUpcoming {
+			return fmt.Errorf("%w: 挑战开始后不能删除", ErrInvalidChallenge)
+		}
+		if err := tx.Where("challenge_id = ?", id).Delete(&models.ChallengeTeam{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("challenge_id = ?", id).Delete(&models.ChallengeParticipant{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Challenge{}, id).Error
+	})
+}
+
+// GetChallenge 获取挑战详情及当前用户的参与情况和排名
+func (s *ChallengeService) GetChallenge(viewerID int64, id uint64) (*models.Challenge, error) {
+	challenge, err := s.loadChallenge(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Model(challenge).Association("Creator").Find(&challenge.Creator); err != nil {
+		return nil, err
+	}
+
+	me, err := s.participant(challenge, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	challenge.Me = me
+	return challenge, nil
+}
+
+// loadChallenge 加载挑战，已结束但未结算的挑战先完成结算
+func (s *ChallengeService) loadChallenge(id uint64) (*models.Challenge, error) {
+	var challenge models.Challenge
+	if err := s.db.First(&challenge, id).Error; err != nil {
+		return nil, err
+	}
+	if challenge.Status == models.ChallengeStatusClosed && challenge.FinalizedAt == nil {
+		if err := s.Finalize(id); err != nil {
+			return nil, err
+		}
+		if err := s.db.First(&challenge, id).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &challenge, nil
+}
+
+// participant 获取用户的参与情况及排名，未参与时返回 nil
+func (s *ChallengeService) participant(challenge *models.Challenge, userID int64) (*models.ChallengeParticipant, error) {
+	var p models.ChallengeParticipant
+	err := s.db.Where("challenge_id = ? AND user_id = ?", challenge.ID, userID).First(&p).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if p.FinalRank != nil {
+		p.Rank = *p.FinalRank
+		return &p, nil
+	}
+	var ahead int64
+	if err := s.db.Model(&models.ChallengeParticipant{}).
+		Where("challenge_id = ? AND progress > ?", challenge.ID, p.Progress).
+		Count(&ahead).Error; err != nil {
+		return nil, err
+	}
+	p.Rank = int(ahead) + 1
+	return &p, nil
+}
+
+// ListChallenges 分页获取挑战列表
+func (s *ChallengeService) ListChallenges(viewerID int64, filter ChallengeFilter, page, pageSize int) ([]models.Challenge, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	now := time.Now()
+
+	query := s.db.Model(&models.Challenge{})
+	order := "end_time ASC, id ASC"
+	switch filter.Status {
+	case models.ChallengeStatusUpcoming:
+		query = query.Where("start_time > ?", now)
+		order = "start_time ASC, id ASC"
+	case models.ChallengeStatusActive:
+		query = query.Where("start_time <= ? AND end_time > ?", now, now)
+	case models.ChallengeStatusClosed:
+		query = query.Where("end_time <= ?", now)
+		order = "end_time DESC, id DESC"
+	case "":
+		query = query.Where("end_time > ?", now)
+	default:
+		return nil, 0, fmt.Errorf("%w: 状态只能是 upcoming/active/closed", ErrInvalidChallenge)
+	}
+	if filter.Joined {
+		joined := s.db.Model(&models.ChallengeParticipant{}).Select("challenge_id").Where("user_id = ?", viewerID)
+		query = query.Where("id IN (?)", joined)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	challenges := make([]models.Challenge, 0)
+	if err := query.Preload("Creator").
+		Order(order).
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&challenges).Error; err != nil {
+		return nil, 0, err
+	}
+	return challenges, total, nil
+}
+
+// JoinChallenge 参加挑战，团队挑战需要指定所属群组作为队伍
+// 加入时立即按挑战时间范围内已有的运动记录计算进度
+func (s *ChallengeService) JoinChallenge(userID int64, id uint64, groupID *uint64) (*models.ChallengeParticipant, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var challenge models.Challenge
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&challenge, id).Error; err != nil {
+			return err
+		}
+		if challenge.Status == models.ChallengeStatusClosed {
+			return fmt.Errorf("%w: 挑战已结束", ErrInvalidChallenge)
+		}
+
+		var count int64
+		if err := tx.Model(&models.ChallengeParticipant{}).
+			Where("challenge_id = ? AND user_id = ?", id, userID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		participant := &models.ChallengeParticipant{ChallengeID: id, UserID: userID}
+		if challenge.Type == models.ChallengeTypeTeam {
+			if groupID == nil {
+				return fmt.Errorf("%w: 团队挑战需要选择所属群组", ErrInvalidChallenge)
+			}
+			role, err := groupRole(tx, *groupID, userID)
+			if err != nil {
+				return err
+			}
+			if role == "" {
+				return fmt.Errorf("%w: 只能代表已加入的群组参加", ErrForbidden)
+			}
+			participant.TeamID = groupID
+			if err := tx.Omit("Group").Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&models.ChallengeTeam{ChallengeID: id, GroupID: *groupID}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Omit("User").Create(participant).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&challenge).
+			UpdateColumn("participant_count", gorm.Expr("participant_count + 1")).Error; err != nil {
+			return err
+		}
+		return updateParticipantProgress(tx, &challenge, participant)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := s.loadChallenge(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.participant(challenge, userID)
+}
+
+// LeaveChallenge 退出挑战，挑战结束后不能退出
+func (s *ChallengeService) LeaveChallenge(userID int64, id uint64) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var challenge models.Challenge
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&challenge, id).Error; err != nil {
+			return err
+		}
+		if challenge.Status == models.ChallengeStatusClosed {
+			return fmt.Errorf("%w: 挑战已结束，排名已冻结", ErrInvalidChallenge)
+		}
+
+		var participant models.ChallengeParticipant
+		err := tx.Where("challenge_id = ? AND user_id = ?", id, userID).First(&participant).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("challenge_id = ? AND user_id = ?", id, userID).
+			Delete(&models.ChallengeParticipant{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&challenge).Where("participant_count > 0").
+			UpdateColumn("participant_count", gorm.Expr("participant_count - 1")).Error; err != nil {
+			return err
+		}
+		if participant.TeamID == nil {
+			return nil
+		}
+		return updateTeamProgress(tx, &challenge, *participant.TeamID)
+	})
+}
+
+// refreshChallengeProgress 重新计算用户在进行中的挑战里的进度，在运动记录增删改的事务中调用
+// 只统计该用户自己的记录，不需要扫描全部参与者
+func refreshChallengeProgress(tx *gorm.DB, userID int64) error {
+	now := time.Now()
+	var participants []models.ChallengeParticipant
+	if err := tx.Where("user_id = ? AND challenge_id IN (?)", userID,
+		tx.Model(&models.Challenge{}).Select("id").
+			Where("finalized_at IS NULL AND start_time <= ? AND end_time > ?", now, now)).
+		Find(&participants).Error; err != nil {
+		return err
+	}
+	if len(participants) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, len(participants))
+	for i, p := range participants {
+		ids[i] = p.ChallengeID
+	}
+	var challenges []models.Challenge
+	if err := tx.Where("id IN ?", ids).Find(&challenges).Error; err != nil {
+		return err
+	}
+	byID := make(map[uint64]*models.Challenge, len(challenges))
+	for i := range challenges {
+		byID[challenges[i].ID] = &challenges[i]
+	}
+
+	for i := range participants {
+		if challenge := byID[participants[i].ChallengeID]; challenge != nil {
+			if err := updateParticipantProgress(tx, challenge, &participants[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// updateParticipantProgress 按挑战时间范围及运动类型统计参与者的进度
+// 只统计公开的记录，排行榜对所有参与者可见，仅关注者可见和仅自己可见的记录不计入进度
+func updateParticipantProgress(tx *gorm.DB, challenge *models.Challenge, p *models.ChallengeParticipant) error {
+	query := tx.Model(&models.SportRecord{}).
+		Where("user_id = ? AND start_time >= ? AND start_time < ?", p.UserID, challenge.StartTime, challenge.EndTime).
+		Where("visibility = ?", models.VisibilityPublic)
+	if len(challenge.SportTypeIDs) > 0 {
+		query = query.Where("sport_type_id IN ?", challenge.SportTypeIDs)
+	}
+
+	var row struct {
+		Progress    float64
+		RecordCount int64
+	}
+	if err := query.Select(challengeMetricExpr[challenge.Metric] + " as progress, COUNT(*) as record_count").
+		Scan(&row).Error; err != nil {
+		return err
+	}
+
+	completedAt := completionTime(challenge.Goal, row.Progress, p.CompletedAt)
+	if err := tx.Model(&models.ChallengeParticipant{}).
+		Where("challenge_id = ? AND user_id = ?", p.ChallengeID, p.UserID).
+		Updates(map[string]interface{}{
+			"progress":     row.Progress,
+			"record_count": row.RecordCount,
+			"completed_at": completedAt,
+		}).Error; err != nil {
+		return err
+	}
+	p.Progress = row.Progress
+	p.RecordCount = row.RecordCount
+	p.CompletedAt = completedAt
+
+	if p.TeamID == nil {
+		return nil
+	}
+	return updateTeamProgress(tx, challenge, *p.TeamID)
+}
+
+// updateTeamProgress 汇总队员进度
+func updateTeamProgress(tx *gorm.DB, challenge *models.Challenge, groupID uint64) error {
+	var team models.ChallengeTeam
+	if err := tx.Where("challenge_id = ? AND group_id = ?", challenge.ID, groupID).First(&team).Error; err != nil {
+		return err
+	}
+
+	var row struct {
+		Progress    float64
+		MemberCount int64
+	}
+	if err := tx.Model(&models.ChallengeParticipant{}).
+		Select("COALESCE(SUM(progress), 0) as progress, COUNT(*) as member_count").
+		Where("challenge_id = ? AND team_id = ?", challenge.ID, groupID).
+		Scan(&row).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&models.ChallengeTeam{}).
+		Where("challenge_id = ? AND group_id = ?", challenge.ID, groupID).
+		Updates(map[string]interface{}{
+			"progress":     row.Progress,
+			"member_count": row.MemberCount,
+			"completed_at": completionTime(challenge.Goal, row.Progress, team.CompletedAt),
+		}).Error
+}
+
+// completionTime 计算达成目标的时间，已达成时保留首次达成的时间
+func completionTime(goal, progress float64, previous *time.Time) *time.Time {
+	if goal <= 0 || progress < goal {
+		return nil
+	}
+	if previous != nil {
+		return previous
+	}
+	now := time.Now()
+	return &now
+}
+
+// GetLeaderboard 分页获取个人排行榜，进度相同时名次相同
+// 结算后的挑战直接返回冻结的最终排名；资料对当前用户不可见的参与者匿名展示
+func (s *ChallengeService) GetLeaderboard(viewerID int64, id uint64, page, pageSize int) ([]models.ChallengeParticipant, int64, error) {
+	challenge, err := s.loadChallenge(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	page, pageSize = normalizePage(page, pageSize)
+	query := s.db.Model(&models.ChallengeParticipant{}).Where("challenge_id = ?", id)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	participants := make([]models.ChallengeParticipant, 0)
+	if err := query.Preload("User").
+		Order("progress DESC, user_id ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&participants).Error; err != nil {
+		return nil, 0, err
+	}
+	if len(participants) == 0 {
+		return participants, total, nil
+	}
+
+	if challenge.FinalizedAt != nil {
+		for i := range participants {
+			if participants[i].FinalRank != nil {
+				participants[i].Rank = *participants[i].FinalRank
+			}
+		}
+	} else {
+		var ahead int64
+		if err := s.db.Model(&models.ChallengeParticipant{}).
+			Where("challenge_id = ? AND progress > ?", id, participants[0].Progress).
+			Count(&ahead).Error; err != nil {
+			return nil, 0, err
+		}
+		offset := (page - 1) * pageSize
+		for i := range participants {
+			switch {
+			case i == 0:
+				participants[i].Rank = int(ahead) + 1
+			case participants[i].Progress == participants[i-1].Progress:
+				participants[i].Rank = participants[i-1].Rank
+			default:
+				participants[i].Rank = offset + i + 1
+			}
+		}
+	}
+
+	userIDs := make([]int64, len(participants))
+	for i, p := range participants {
+		userIDs[i] = p.UserID
+	}
+	visible, err := visibleProfiles(s.db, viewerID, userIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range participants {
+		if !visible[participants[i].UserID] {
+			participants[i].UserID = 0
+			participants[i].User = nil
+		}
+	}
+	return participants, total, nil
+}
+
+// GetTeamLeaderboard 分页获取团队排行榜，规则与个人排行榜相同
+func (s *ChallengeService) GetTeamLeaderboard(id uint64, page, pageSize int) ([]models.ChallengeTeam, int64, error) {
+	challenge, err := s.loadChallenge(id)
+	if err != nil {
+		return nil, 0, err
+	}
+	if challenge.Type != models.ChallengeTypeTeam {
+		return nil, 0, fmt.Errorf("%w: 该挑战不是团队挑战", ErrInvalidChallenge)
+	}
+
+	page, pageSize = normalizePage(page, pageSize)
+	query := s.db.Model(&models.ChallengeTeam{}).Where("challenge_id = ? AND member_count > 0", id)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	teams := make([]models.ChallengeTeam, 0)
+	if err := query.Preload("Group", func(db *gorm.DB) *gorm.DB {
+		// 只返回群组的公开资料，不包含邀请码
+		return db.Select("id", "name", "avatar_url", "owner_id", "join_mode", "member_count")
+	}).
+		Order("progress DESC, group_id ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&teams).Error; err != nil {
+		return nil, 0, err
+	}
+	if len(teams) == 0 {
+		return teams, total, nil
+	}
+
+	if challenge.FinalizedAt != nil {
+		for i := range teams {
+			if teams[i].FinalRank != nil {
+				teams[i].Rank = *teams[i].FinalRank
+			}
+		}
+		return teams, total, nil
+	}
+
+	var ahead int64
+	if err := s.db.Model(&models.ChallengeTeam{}).
+		Where("challenge_id = ? AND member_count > 0 AND progress > ?", id, teams[0].Progress).
+		Count(&ahead).Error; err != nil {
+		return nil, 0, err
+	}
+	offset := (page - 1) * pageSize
+	for i := range teams {
+		switch {
+		case i == 0:
+			teams[i].Rank = int(ahead) + 1
+		case teams[i].Progress == teams[i-1].Progress:
+			teams[i].Rank = teams[i-1].Rank
+		default:
+			teams[i].Rank = offset + i + 1
+		}
+	}
+	return teams, total, nil
+}
+
+// Finalize 结算已结束的挑战，写入最终排名后进度和排名不再变化
+func (s *ChallengeService) Finalize(id uint64) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var challenge models.Challenge
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&challenge, id).Error; err != nil {
+			return err
+		}
+		if challenge.FinalizedAt != nil || time.Now().Before(challenge.EndTime) {
+			return nil
+		}
+
+		// RANK() 在进度相同时给出相同名次，并跳过后续名次
+		if err := tx.Exec("UPDATE challenge_participants p JOIN ("+
+			"SELECT user_id, RANK() OVER (ORDER BY progress DESC) AS final_rank "+
+			"FROM challenge_participants WHERE challenge_id = ?"+
+			") ranked ON ranked.user_id = p.user_id "+
+			"SET p.final_rank = ranked.final_rank WHERE p.challenge_id = ?", id, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE challenge_teams t JOIN ("+
+			"SELECT group_id, RANK() OVER (ORDER BY progress DESC) AS final_rank "+
+			"FROM challenge_teams WHERE challenge_id = ? AND member_count > 0"+
+			") ranked ON ranked.group_id = t.group_id "+
+			"SET t.final_rank = ranked.final_rank WHERE t.challenge_id = ?", id, id).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		return tx.Model(&challenge).Update("finalized_at", now).Error
+	})
+}
+
+// FinalizeDue 结算所有已结束但未结算的挑战，返回结算的数量
+func (s *ChallengeService) FinalizeDue(now time.Time) (int, error) {
+	var ids []uint64
+	if err := s.db.Model(&models.Challenge{}).
+		Where("finalized_at IS NULL AND end_time <= ?", now).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	for i, id := range ids {
+		if err := s.Finalize(id); err != nil {
+			return i, err
+		}
+	}
+	return len(ids), nil
+}
+
+// StartFinalizer 定期结算已结束的挑战，在后台协程中运行
+func (s *ChallengeService) StartFinalizer(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if finalized, err := s.FinalizeDue(time.Now()); err != nil {
+			log.Printf("结算挑战失败: %v", err)
+		} else if finalized > 0 {
+			log.Printf("已结算挑战 %d 个", finalized)
+		}
+		<-ticker.C
+	}
+}
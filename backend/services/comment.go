@@ -0,0 +1,392 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sports-app/backend/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxCommentLength 评论内容最大长度
+const maxCommentLength = 500
+
+// ErrInvalidComment 评论参数校验失败
+var ErrInvalidComment = errors.New("无效的评论")
+
+// CommentService 评论服务
+type CommentService struct {
+	db *gorm.DB
+}
+
+// NewCommentService 创建评论服务实例
+func NewCommentService(db *gorm.DB) *CommentService {
+	return &CommentService{db: db}
+}
+
+// CreateComment 发表评论，parentID 不为空时为回复
+func (s *CommentService) CreateComment(userID, checkInID int64, content string, parentID *uint64) (*models.Comment, error) {
+	content, err := normalizeCommentContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	checkIn, err := findVisibleCheckIn(s.db, userID, checkInID)
+	if err != nil {
+		return nil, err
+	}
+
+	moderation, err := GetModerator().Check(ModerationFieldComment, content)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &models.Comment{
+		UserID:    uint64(userID),
+		CheckInID: uint64(checkInID),
+		Content:   moderation.Text,
+		IsHidden:  moderation.NeedsReview,
+	}
+
+	var parent models.Comment
+	if parentID != nil {
+		if err := s.db.Where("id = ? AND check_in_id = ?", *parentID, checkInID).First(&parent).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: 回复的评论不存在", ErrInvalidComment)
+			}
+			return nil, err
+		}
+		if parent.IsDeleted {
+			return nil, fmt.Errorf("%w: 不能回复已删除的评论", ErrInvalidComment)
+		}
+		blocked, err := isBlocked(s.db, userID, int64(parent.UserID))
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			return nil, fmt.Errorf("%w: 无法回复该用户", ErrForbidden)
+		}
+
+		rootID := parent.ID
+		if parent.RootID != nil {
+			rootID = *parent.RootID
+		}
+		comment.ParentID = &parent.ID
+		comment.RootID = &rootID
+	}
+
+	var queue eventQueue
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User", "CheckIn", "Parent").Create(comment).Error; err != nil {
+			return err
+		}
+		if err := saveCommentHashtags(tx, comment.ID, comment.Content); err != nil {
+			return err
+		}
+		// 待审核的评论审核通过前不通知
+		if moderation.NeedsReview {
+			return queueReview(tx, models.ModerationTargetComment, comment.ID, userID, content, moderation.Matched)
+		}
+
+		// 回复通知被回复者；打卡作者未收到回复通知时再通知打卡作者
+		// 已收到回复或评论通知的用户不再收到提及通知
+		notified := map[int64]bool{int64(checkIn.UserID): true}
+		if comment.ParentID != nil {
+			notified[int64(parent.UserID)] = true
+			if err := notify(tx, &queue, notifyInput{
+				UserID:     int64(parent.UserID),
+				ActorID:    userID,
+				Type:       models.NotificationTypeReply,
+				TargetType: models.NotificationTargetComment,
+				TargetID:   parent.ID,
+				Content:    content,
+			}); err != nil {
+				return err
+			}
+		}
+		if comment.ParentID == nil || parent.UserID != checkIn.UserID {
+			if err := notify(tx, &queue, notifyInput{
+				UserID:     int64(checkIn.UserID),
+				ActorID:    userID,
+				Type:       models.NotificationTypeComment,
+				TargetType: models.NotificationTargetCheckIn,
+				TargetID:   checkIn.ID,
+				Content:    content,
+			}); err != nil {
+				return err
+			}
+		}
+		return notifyMentions(tx, &queue, mentionInput{
+			ActorID:    userID,
+			CheckInID:  checkIn.ID,
+			TargetType: models.NotificationTargetComment,
+			TargetID:   comment.ID,
+			Text:       comment.Content,
+			Skip:       notified,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Preload("User").First(comment, comment.ID).Error; err != nil {
+		return nil, err
+	}
+
+	if int64(checkIn.UserID) != userID && !comment.IsHidden {
+		queue.add(int64(checkIn.UserID), EventComment, comment)
+	}
+	queue.publish(s.db)
+	return comment, nil
+}
+
+// UpdateComment 编辑评论，作者或管理员可操作
+func (s *CommentService) UpdateComment(userID, id int64, content string) (*models.Comment, error) {
+	content, err := normalizeCommentContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.findEditableComment(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.IsDeleted {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	moderation, err := GetModerator().Check(ModerationFieldComment, content)
+	if err != nil {
+		return nil, err
+	}
+
+	// 编辑前已提及的用户不再重复通知
+	mentioned, err := resolveMentions(s.db, comment.Content)
+	if err != nil {
+		return nil, err
+	}
+	skip := make(map[int64]bool, len(mentioned))
+	for _, id := range mentioned {
+		skip[id] = true
+	}
+
+	updates := map[string]interface{}{
+		"content":   moderation.Text,
+		"edited_at": time.Now(),
+	}
+	var queue eventQueue
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := saveCommentHashtags(tx, comment.ID, moderation.Text); err != nil {
+			return err
+		}
+		if !moderation.NeedsReview {
+			if err := tx.Model(comment).Updates(updates).Error; err != nil {
+				return err
+			}
+			return notifyMentions(tx, &queue, mentionInput{
+				ActorID:    int64(comment.UserID),
+				CheckInID:  comment.CheckInID,
+				TargetType: models.NotificationTargetComment,
+				TargetID:   comment.ID,
+				Text:       moderation.Text,
+				Skip:       skip,
+			})
+		}
+		updates["is_hidden"] = true
+		if err := tx.Model(comment).Updates(updates).Error; err != nil {
+			return err
+		}
+		return queueReview(tx, models.ModerationTargetComment, comment.ID, int64(comment.UserID), content, moderation.Matched)
+	})
+	if err != nil {
+		return nil, err
+	}
+	queue.publish(s.db)
+
+	if err := s.db.Preload("User").First(comment, comment.ID).Error; err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// DeleteComment 删除评论，作者或管理员可操作；仍有回复的评论保留“已删除”占位
+func (s *CommentService) DeleteComment(userID, id int64) error {
+	comment, err := s.findEditableComment(userID, id)
+	if err != nil {
+		return err
+	}
+
+	var replies int64
+	if err := s.db.Model(&models.Comment{}).Where("parent_id = ?", comment.ID).Count(&replies).Error; err != nil {
+		return err
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(&models.CommentHashtag{}).Error; err != nil {
+			return err
+		}
+		if replies == 0 {
+			return tx.Delete(comment).Error
+		}
+		return tx.Model(comment).Updates(map[string]interface{}{
+			"content":    "",
+			"is_deleted": true,
+		}).Error
+	})
+}
+
+// ListComments 分页获取打卡的评论，按顶层评论分页；flat 为 true 时每个顶层评论下的回复平铺展示
+// 与当前用户存在屏蔽关系的用户发表的评论不返回，树形展示时其下的回复一并隐藏
+func (s *CommentService) ListComments(viewerID, checkInID int64, page, pageSize int, flat bool) ([]models.Comment, int64, error) {
+	if _, err := findVisibleCheckIn(s.db, viewerID, checkInID); err != nil {
+		return nil, 0, err
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	rootQuery := s.db.Model(&models.Comment{}).Where("check_in_id = ? AND parent_id IS NULL", checkInID)
+	rootQuery = excludeBlocked(rootQuery, "comments.user_id", viewerID)
+
+	var total int64
+	if err := rootQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	roots := make([]models.Comment, 0)
+	if err := rootQuery.Preload("User").
+		Order("created_at ASC, id ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&roots).Error; err != nil {
+		return nil, 0, err
+	}
+	if len(roots) == 0 {
+		return roots, total, nil
+	}
+
+	rootIDs := make([]uint64, len(roots))
+	for i, root := range roots {
+		rootIDs[i] = root.ID
+	}
+
+	var replies []models.Comment
+	if err := excludeBlocked(s.db, "comments.user_id", viewerID).Preload("User").
+		Where("root_id IN ?", rootIDs).
+		Order("created_at ASC, id ASC").
+		Find(&replies).Error; err != nil {
+		return nil, 0, err
+	}
+
+	for i := range roots {
+		maskDeletedComment(&roots[i])
+		maskHiddenComment(viewerID, &roots[i])
+	}
+	for i := range replies {
+		maskDeletedComment(&replies[i])
+		maskHiddenComment(viewerID, &replies[i])
+	}
+
+	if flat {
+		return flattenThreads(roots, replies), total, nil
+	}
+	return buildCommentTree(roots, replies), total, nil
+}
+
+// findEditableComment 查找当前用户有权编辑或删除的评论
+func (s *CommentService) findEditableComment(userID, id int64) (*models.Comment, error) {
+	var comment models.Comment
+	if err := s.db.First(&comment, id).Error; err != nil {
+		return nil, err
+	}
+	if int64(comment.UserID) == userID {
+		return &comment, nil
+	}
+
+	admin, err := isAdmin(s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin {
+		return nil, ErrForbidden
+	}
+	return &comment, nil
+}
+
+// buildCommentTree 将回复按父子关系组装为树
+func buildCommentTree(roots, replies []models.Comment) []models.Comment {
+	children := make(map[uint64][]models.Comment)
+	for _, reply := range replies {
+		children[*reply.ParentID] = append(children[*reply.ParentID], reply)
+	}
+
+	var build func(c models.Comment) models.Comment
+	build = func(c models.Comment) models.Comment {
+		for _, child := range children[c.ID] {
+			c.Replies = append(c.Replies, build(child))
+		}
+		return c
+	}
+
+	tree := make([]models.Comment, len(roots))
+	for i, root := range roots {
+		tree[i] = build(root)
+	}
+	return tree
+}
+
+// flattenThreads 将每个顶层评论下的所有回复按时间平铺，并标注被回复的用户
+func flattenThreads(roots, replies []models.Comment) []models.Comment {
+	authors := make(map[uint64]models.PublicUser, len(roots)+len(replies))
+	for _, c := range roots {
+		authors[c.ID] = c.User
+	}
+	for _, c := range replies {
+		authors[c.ID] = c.User
+	}
+
+	byRoot := make(map[uint64][]models.Comment)
+	for _, reply := range replies {
+		if *reply.ParentID != *reply.RootID {
+			if replyTo, ok := authors[*reply.ParentID]; ok {
+				reply.ReplyTo = &replyTo
+			}
+		}
+		byRoot[*reply.RootID] = append(byRoot[*reply.RootID], reply)
+	}
+
+	threads := make([]models.Comment, len(roots))
+	for i, root := range roots {
+		root.Replies = byRoot[root.ID]
+		threads[i] = root
+	}
+	return threads
+}
+
+// maskDeletedComment 隐藏已删除评论的内容和作者
+func maskDeletedComment(comment *models.Comment) {
+	if !comment.IsDeleted {
+		return
+	}
+	comment.Content = models.DeletedCommentPlaceholder
+	comment.UserID = 0
+	comment.User = models.PublicUser{}
+}
+
+// maskHiddenComment 对作者以外的用户隐藏待审核或被隐藏评论的内容
+func maskHiddenComment(viewerID int64, comment *models.Comment) {
+	if !comment.IsHidden || comment.IsDeleted || int64(comment.UserID) == viewerID {
+		return
+	}
+	comment.Content = models.HiddenCommentPlaceholder
+}
+
+// normalizeCommentContent 校验并清理评论内容
+func normalizeCommentContent(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", fmt.Errorf("%w: 评论内容不能为空", ErrInvalidComment)
+	}
+	if len([]rune(content)) > maxCommentLength {
+		return "", fmt.Errorf("%w: 评论内容不能超过%d个字符", ErrInvalidComment, maxCommentLength)
+	}
+	return content, nil
+}
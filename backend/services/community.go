@@ -0,0 +1,362 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sports-app/backend/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 打卡内容限制
+const (
+	maxCheckInImages      = 9
+	maxCheckInDescription = 2000
+	maxFeedPageSize       = 50
+)
+
+// ErrInvalidCheckIn 打卡参数校验失败
+var ErrInvalidCheckIn = errors.New("无效的打卡")
+
+// ErrForbidden 无权操作该资源
+var ErrForbidden = errors.New("无权操作")
+
+// CreateCheckInInput 发布打卡的参数
+type CreateCheckInInput struct {
+	SportTypeID uint64   `json:"sport_type_id" binding:"required"`
+	Duration    int      `json:"duration"`
+	Images      []string `json:"images"`
+	Description string   `json:"description"`
+	Visibility  string   `json:"visibility"` // public/followers/private，为空时使用账号的默认设置
+	IsShared    *bool    `json:"is_shared"`  // 兼容旧版客户端，未指定 visibility 时 true 对应 public
+}
+
+// CommunityService 社区打卡服务
+type CommunityService struct {
+	db *gorm.DB
+}
+
+// NewCommunityService 创建社区打卡服务实例
+func NewCommunityService(db *gorm.DB) *CommunityService {
+	return &CommunityService{db: db}
+}
+
+// CreateCheckIn 发布打卡
+func (s *CommunityService) CreateCheckIn(userID int64, input CreateCheckInInput) (*models.CheckIn, error) {
+	images := make([]string, 0, len(input.Images))
+	for _, url := range input.Images {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			continue
+		}
+		if strings.Contains(url, ",") {
+			return nil, fmt.Errorf("%w: 图片地址不能包含逗号", ErrInvalidCheckIn)
+		}
+		images = append(images, url)
+	}
+	if len(images) > maxCheckInImages {
+		return nil, fmt.Errorf("%w: 最多上传%d张图片", ErrInvalidCheckIn, maxCheckInImages)
+	}
+
+	description := strings.TrimSpace(input.Description)
+	if len([]rune(description)) > maxCheckInDescription {
+		return nil, fmt.Errorf("%w: 描述不能超过%d个字符", ErrInvalidCheckIn, maxCheckInDescription)
+	}
+	if description == "" && len(images) == 0 {
+		return nil, fmt.Errorf("%w: 描述和图片不能同时为空", ErrInvalidCheckIn)
+	}
+	if input.Duration < 0 {
+		return nil, fmt.Errorf("%w: 运动时长不能为负数", ErrInvalidCheckIn)
+	}
+	if !validVisibility(input.Visibility) {
+		return nil, fmt.Errorf("%w: 可见范围只能是 public/followers/private", ErrInvalidCheckIn)
+	}
+
+	moderation, err := GetModerator().Check(ModerationFieldCheckIn, description)
+	if err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := s.db.Model(&models.SportType{}).Where("id = ?", input.SportTypeID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("%w: 运动类型不存在", ErrInvalidCheckIn)
+	}
+
+	visibility := input.Visibility
+	switch {
+	case visibility != "":
+	case input.IsShared != nil && *input.IsShared:
+		visibility = models.VisibilityPublic
+	case input.IsShared != nil:
+		visibility = models.VisibilityPrivate
+	default:
+		if visibility, err = defaultVisibility(s.db, userID, "default_check_in_visibility"); err != nil {
+			return nil, err
+		}
+	}
+
+	checkIn := &models.CheckIn{
+		UserID:      uint64(userID),
+		SportTypeID: input.SportTypeID,
+		Duration:    input.Duration,
+		Images:      strings.Join(images, ","),
+		Description: moderation.Text,
+		Visibility:  visibility,
+		IsHidden:    moderation.NeedsReview,
+	}
+	var queue eventQueue
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User", "SportType", "LikeCount").Create(checkIn).Error; err != nil {
+			return err
+		}
+		if err := saveCheckInHashtags(tx, checkIn.ID, checkIn.Description); err != nil {
+			return err
+		}
+		// 待审核的打卡审核通过前不通知被提及的用户
+		if moderation.NeedsReview {
+			return queueReview(tx, models.ModerationTargetCheckIn, checkIn.ID, userID, description, moderation.Matched)
+		}
+		return notifyMentions(tx, &queue, mentionInput{
+			ActorID:    userID,
+			CheckInID:  checkIn.ID,
+			TargetType: models.NotificationTargetCheckIn,
+			TargetID:   checkIn.ID,
+			Text:       checkIn.Description,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	queue.publish(s.db)
+	return s.GetCheckIn(userID, int64(checkIn.ID))
+}
+
+// findVisibleCheckIn 查找对当前用户可见的打卡（在可见范围内且未隐藏，或本人发布）
+func findVisibleCheckIn(db *gorm.DB, viewerID, id int64) (*models.CheckIn, error) {
+	var checkIn models.CheckIn
+	if err := visibleCheckIns(db.Where("check_ins.id = ?", id), viewerID).
+		First(&checkIn).Error; err != nil {
+		return nil, err
+	}
+	return &checkIn, nil
+}
+
+// isAdmin 判断用户是否为管理员
+func isAdmin(db *gorm.DB, userID int64) (bool, error) {
+	var user models.User
+	if err := db.Select("id", "role").First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return user.Role == "admin", nil
+}
+
+// GetCheckIn 获取单条打卡，不在可见范围内或已隐藏的打卡仅作者可见
+func (s *CommunityService) GetCheckIn(viewerID, id int64) (*models.CheckIn, error) {
+	var checkIn models.CheckIn
+	if err := visibleCheckIns(s.db.Preload("User").Preload("SportType").Where("check_ins.id = ?", id), viewerID).
+		First(&checkIn).Error; err != nil {
+		return nil, err
+	}
+
+	items := []models.CheckIn{checkIn}
+	if err := s.fillCounts(viewerID, items); err != nil {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// DeleteCheckIn 删除打卡，仅作者可删除
+func (s *CommunityService) DeleteCheckIn(userID, id int64) error {
+	var checkIn models.CheckIn
+	if err := s.db.Select("id", "user_id").First(&checkIn, id).Error; err != nil {
+		return err
+	}
+	if int64(checkIn.UserID) != userID {
+		return ErrForbidden
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("check_in_id = ?", id).Delete(&models.CheckInHashtag{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.CheckIn{}, id).Error
+	})
+}
+
+// GetFeed 获取全站公开打卡动态，仅关注者可见的打卡以及屏蔽、静音用户的打卡不出现在全站动态中
+func (s *CommunityService) GetFeed(viewerID int64, page, pageSize int) ([]models.CheckIn, int64, error) {
+	query := s.db.Model(&models.CheckIn{}).Where("visibility = ? AND is_hidden = ?", models.VisibilityPublic, false)
+	query = excludeMuted(excludeBlocked(query, "check_ins.user_id", viewerID), "check_ins.user_id", viewerID)
+	return s.paginate(viewerID, query, page, pageSize)
+}
+
+// GetUserFeed 获取某个用户的打卡动态，本人可以看到全部打卡，其他人只能看到可见范围内的打卡
+// 双方存在屏蔽关系时返回空列表
+func (s *CommunityService) GetUserFeed(viewerID, userID int64, page, pageSize int) ([]models.CheckIn, int64, error) {
+	query := s.db.Model(&models.CheckIn{}).Where("check_ins.user_id = ?", userID)
+	if viewerID != userID {
+		query = sharedCheckIns(query, viewerID)
+	}
+	return s.paginate(viewerID, query, page, pageSize)
+}
+
+// GetFollowingFeed 获取关注用户公开及仅关注者可见的打卡，不包含静音用户，按发布时间倒序游标分页
+// cursor 为上一页返回的 nextCursor，为空时从最新一条开始
+func (s *CommunityService) GetFollowingFeed(viewerID int64, cursor string, limit int) ([]models.CheckIn, string, error) {
+	_, limit = normalizePage(1, limit)
+
+	// 子查询走 uk_follower_followee 索引，打卡按 (user_id, created_at) 索引逐个用户取最新数据
+	followees := s.db.Model(&models.Follow{}).Select("followee_id").Where("follower_id = ?", viewerID)
+	query := s.db.Model(&models.CheckIn{}).
+		Where("visibility IN ? AND is_hidden = ? AND user_id IN (?)",
+			[]string{models.VisibilityPublic, models.VisibilityFollowers}, false, followees)
+	// 屏蔽时已解除关注关系，这里再排除一次以防关系数据不一致
+	query = excludeMuted(excludeBlocked(query, "check_ins.user_id", viewerID), "check_ins.user_id", viewerID)
+
+	if cursor != "" {
+		createdAt, id, err := decodeFeedCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		query = query.Where("created_at < ? OR (created_at = ? AND id < ?)", createdAt, createdAt, id)
+	}
+
+	checkIns := make([]models.CheckIn, 0, limit+1)
+	if err := query.Preload("User").Preload("SportType").
+		Order("created_at DESC, id DESC").
+		Limit(limit + 1).
+		Find(&checkIns).Error; err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(checkIns) > limit {
+		checkIns = checkIns[:limit]
+		last := checkIns[limit-1]
+		nextCursor = encodeFeedCursor(last.CreatedAt, last.ID)
+	}
+
+	if err := s.fillCounts(viewerID, checkIns); err != nil {
+		return nil, "", err
+	}
+	return checkIns, nextCursor, nil
+}
+
+// encodeFeedCursor 将最后一条打卡的发布时间和ID编码为游标
+func encodeFeedCursor(createdAt time.Time, id uint64) string {
+	raw := strconv.FormatInt(createdAt.UnixNano(), 10) + "_" + strconv.FormatUint(id, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeFeedCursor 解析游标
+func decodeFeedCursor(cursor string) (time.Time, uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("%w: 游标格式错误", ErrInvalidCheckIn)
+	}
+	parts := strings.SplitN(string(raw), "_", 2)
+	if len(parts) != 2 {
+		return time.Time{}, 0, fmt.Errorf("%w: 游标格式错误", ErrInvalidCheckIn)
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("%w: 游标格式错误", ErrInvalidCheckIn)
+	}
+	id, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("%w: 游标格式错误", ErrInvalidCheckIn)
+	}
+	return time.Unix(0, nanos), id, nil
+}
+
+// paginate 分页查询打卡并填充评论数及点赞状态
+func (s *CommunityService) paginate(viewerID int64, query *gorm.DB, page, pageSize int) ([]models.CheckIn, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	checkIns := make([]models.CheckIn, 0)
+	if err := query.Preload("User").Preload("SportType").
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&checkIns).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.fillCounts(viewerID, checkIns); err != nil {
+		return nil, 0, err
+	}
+	return checkIns, total, nil
+}
+
+// fillCounts 批量查询并填充打卡的评论数及当前用户是否已点赞
+func (s *CommunityService) fillCounts(viewerID int64, checkIns []models.CheckIn) error {
+	if len(checkIns) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, len(checkIns))
+	for i, c := range checkIns {
+		ids[i] = c.ID
+	}
+
+	var commentRows []struct {
+		CheckInID uint64
+		Total     int64
+	}
+	if err := s.db.Model(&models.Comment{}).
+		Select("check_in_id, COUNT(*) as total").
+		Where("check_in_id IN ? AND is_deleted = ? AND is_hidden = ?", ids, false, false).
+		Group("check_in_id").
+		Scan(&commentRows).Error; err != nil {
+		return err
+	}
+
+	var likedIDs []uint64
+	if err := s.db.Model(&models.Like{}).
+		Where("user_id = ? AND check_in_id IN ?", viewerID, ids).
+		Pluck("check_in_id", &likedIDs).Error; err != nil {
+		return err
+	}
+
+	comments := make(map[uint64]int64, len(commentRows))
+	for _, row := range commentRows {
+		comments[row.CheckInID] = row.Total
+	}
+	liked := make(map[uint64]bool, len(likedIDs))
+	for _, id := range likedIDs {
+		liked[id] = true
+	}
+	for i := range checkIns {
+		checkIns[i].CommentCount = comments[checkIns[i].ID]
+		checkIns[i].LikedByMe = liked[checkIns[i].ID]
+	}
+	return nil
+}
+
+// normalizePage 规范分页参数
+func normalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > maxFeedPageSize {
+		pageSize = maxFeedPageSize
+	}
+	return page, pageSize
+}
@@ -0,0 +1,657 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sports-app/backend/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 活动内容限制
+const (
+	maxEventTitleLength       = 100
+	maxEventDescriptionLength = 2000
+	maxEventLocationLength    = 200
+	// eventRecordWindow 关联运动记录时，记录开始时间允许超出活动时间的范围
+	eventRecordWindow = 12 * time.Hour
+)
+
+// ErrInvalidEvent 活动参数校验失败
+var ErrInvalidEvent = errors.New("无效的活动")
+
+// EventInput 创建或修改活动的参数
+type EventInput struct {
+	GroupID     *uint64   `json:"group_id"` // 为空时创建公开活动，修改时忽略
+	Title       string    `json:"title" binding:"required"`
+	Description string    `json:"description"`
+	SportTypeID int64     `json:"sport_type_id" binding:"required"`
+	Location    string    `json:"location"`
+	StartTime   time.Time `json:"start_time" binding:"required"`
+	EndTime     time.Time `json:"end_time"`
+	Capacity    int       `json:"capacity"`
+}
+
+// EventFilter 活动列表筛选条件
+type EventFilter struct {
+	GroupID *uint64 `form:"group_id"`
+	Past    bool    `form:"past"`   // 为 true 时返回已结束的活动，否则返回未结束的活动
+	Joined  bool    `form:"joined"` // 仅返回自己报名（参加、可能参加或候补）的活动
+}
+
+// AttendanceInput 签到确认参数
+type AttendanceInput struct {
+	UserID   int64 `json:"user_id" binding:"required"`
+	Attended bool  `json:"attended"`
+}
+
+// EventService 活动服务
+type EventService struct {
+	db *gorm.DB
+}
+
+// NewEventService 创建活动服务实例
+func NewEventService(db *gorm.DB) *EventService {
+	return &EventService{db: db}
+}
+
+// validateEventInput 校验并规范活动参数
+func validateEventInput(tx *gorm.DB, input *EventInput) error {
+	input.Title = strings.TrimSpace(input.Title)
+	input.Description = strings.TrimSpace(input.Description)
+	input.Location = strings.TrimSpace(input.Location)
+
+	if input.Title == "" {
+		return fmt.Errorf("%w: 标题不能为空", ErrInvalidEvent)
+	}
+	if len([]rune(input.Title)) > maxEventTitleLength {
+		return fmt.Errorf("%w: 标题不能超过%d个字符", ErrInvalidEvent, maxEventTitleLength)
+	}
+	if len([]rune(input.Description)) > maxEventDescriptionLength {
+		return fmt.Errorf("%w: 描述不能超过%d个字符", ErrInvalidEvent, maxEventDescriptionLength)
+	}
+	if len([]rune(input.Location)) > maxEventLocationLength {
+		return fmt.Errorf("%w: 地点不能超过%d个字符", ErrInvalidEvent, maxEventLocationLength)
+	}
+	if input.Capacity < 0 {
+		return fmt.Errorf("%w: 人数上限不能为负数", ErrInvalidEvent)
+	}
+	if input.EndTime.IsZero() {
+		input.EndTime = input.StartTime
+	}
+	if input.EndTime.Before(input.StartTime) {
+		return fmt.Errorf("%w: 结束时间不能早于开始时间", ErrInvalidEvent)
+	}
+
+	// 活动资料与群组资料使用相同的审核策略
+	for _, text := range []*string{&input.Title, &input.Description, &input.Location} {
+		moderation, err := GetModerator().Check(ModerationFieldGroup, *text)
+		if err != nil {
+			return err
+		}
+		if moderation.NeedsReview {
+			return fmt.Errorf("%w，请修改后重试", ErrSensitiveContent)
+		}
+		*text = moderation.Text
+	}
+
+	var count int64
+	if err := tx.Model(&models.SportType{}).Where("id = ?", input.SportTypeID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: 运动类型不存在", ErrInvalidEvent)
+	}
+	return nil
+}
+
+// findVisibleEvent 查找对当前用户可见的活动（公开活动，或所属群组的成员）
+func findVisibleEvent(tx *gorm.DB, viewerID int64, id uint64) (*models.Event, error) {
+	var event models.Event
+	if err := tx.First(&event, id).Error; err != nil {
+		return nil, err
+	}
+	if event.GroupID != nil {
+		role, err := groupRole(tx, *event.GroupID, viewerID)
+		if err != nil {
+			return nil, err
+		}
+		// 对非成员隐藏群组活动的存在
+		if role == "" {
+			return nil, gorm.ErrRecordNotFound
+		}
+	}
+	return &event, nil
+}
+
+// lockVisibleEvent 在事务中锁定活动，串行化同一活动的报名操作
+func lockVisibleEvent(tx *gorm.DB, viewerID int64, id uint64) (*models.Event, error) {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").First(&models.Event{}, id).Error; err != nil {
+		return nil, err
+	}
+	return findVisibleEvent(tx, viewerID, id)
+}
+
+// requireEventManager 要求用户为活动组织者，群组活动的群主和管理员也可以管理
+func requireEventManager(tx *gorm.DB, userID int64, event *models.Event) error {
+	if event.OrganizerID == userID {
+		return nil
+	}
+	if event.GroupID != nil {
+		role, err := groupRole(tx, *event.GroupID, userID)
+		if err != nil {
+			return err
+		}
+		if isGroupManager(role) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: 仅活动组织者可以操作", ErrForbidden)
+}
+
+// CreateEvent 创建活动，组织者自动报名参加
+// 群组活动只能由群主和管理员创建
+func (s *EventService) CreateEvent(userID int64, input EventInput) (*models.Event, error) {
+	if err := validateEventInput(s.db, &input); err != nil {
+		return nil, err
+	}
+	if !input.StartTime.After(time.Now()) {
+		return nil, fmt.Errorf("%w: 开始时间必须晚于当前时间", ErrInvalidEvent)
+	}
+	if input.GroupID != nil {
+		if _, err := requireGroupManager(s.db, *input.GroupID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	event := &models.Event{
+		GroupID:     input.GroupID,
+		OrganizerID: userID,
+		Title:       input.Title,
+		Description: input.Description,
+		SportTypeID: input.SportTypeID,
+		Location:    input.Location,
+		StartTime:   input.StartTime,
+		EndTime:     input.EndTime,
+		Capacity:    input.Capacity,
+		Status:      models.EventStatusScheduled,
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Organizer", "SportType").Create(event).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit("User").Create(&models.EventRSVP{
+			EventID: event.ID,
+			UserID:  userID,
+			Status:  models.RSVPGoing,
+		}).Error; err != nil {
+			return err
+		}
+		return recountRSVPs(tx, event.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetEvent(userID, event.ID)
+}
+
+// UpdateEvent 修改活动，人数上限调大时候补用户依次转为参加
+// 调小人数上限不会取消已参加用户的名额
+func (s *EventService) UpdateEvent(userID int64, id uint64, input EventInput) (*models.Event, error) {
+	if err := validateEventInput(s.db, &input); err != nil {
+		return nil, err
+	}
+
+	var queue eventQueue
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		event, err := lockVisibleEvent(tx, userID, id)
+		if err != nil {
+			return err
+		}
+		if err := requireEventManager(tx, userID, event); err != nil {
+			return err
+		}
+		if event.Status == models.EventStatusCanceled {
+			return fmt.Errorf("%w: 活动已取消", ErrInvalidEvent)
+		}
+
+		event.Title = input.Title
+		event.Description = input.Description
+		event.SportTypeID = input.SportTypeID
+		event.Location = input.Location
+		event.StartTime = input.StartTime
+		event.EndTime = input.EndTime
+		event.Capacity = input.Capacity
+		if err := tx.Model(event).Select("title", "description", "sport_type_id", "location",
+			"start_time", "end_time", "capacity").Updates(event).Error; err != nil {
+			return err
+		}
+		if err := promoteWaitlist(tx, &queue, event); err != nil {
+			return err
+		}
+		return recountRSVPs(tx, event.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	queue.publish(s.db)
+	return s.GetEvent(userID, id)
+}
+
+// CancelEvent 取消活动并通知已报名的用户
+func (s *EventService) CancelEvent(userID int64, id uint64) error {
+	var queue eventQueue
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		event, err := lockVisibleEvent(tx, userID, id)
+		if err != nil {
+			return err
+		}
+		if err := requireEventManager(tx, userID, event); err != nil {
+			return err
+		}
+		if event.Status == models.EventStatusCanceled {
+			return nil
+		}
+
+		if err := tx.Model(event).Update("status", models.EventStatusCanceled).Error; err != nil {
+			return err
+		}
+
+		var userIDs []int64
+		if err := tx.Model(&models.EventRSVP{}).
+			Where("event_id = ? AND status IN ?", id, []string{models.RSVPGoing, models.RSVPMaybe, models.RSVPWaitlisted}).
+			Pluck("user_id", &userIDs).Error; err != nil {
+			return err
+		}
+		for _, uid := range userIDs {
+			if err := notify(tx, &queue, notifyInput{
+				UserID:     uid,
+				ActorID:    userID,
+				Type:       models.NotificationTypeEventCanceled,
+				TargetType: models.NotificationTargetEvent,
+				TargetID:   event.ID,
+				Content:    event.Title,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	queue.publish(s.db)
+	return nil
+}
+
+// GetEvent 获取活动详情
+func (s *EventService) GetEvent(viewerID int64, id uint64) (*models.Event, error) {
+	if _, err := findVisibleEvent(s.db, viewerID, id); err != nil {
+		return nil, err
+	}
+
+	var event models.Event
+	if err := s.db.Preload("Organizer").Preload("SportType").First(&event, id).Error; err != nil {
+		return nil, err
+	}
+	events := []models.Event{event}
+	if err := s.fillMyRSVP(viewerID, events); err != nil {
+		return nil, err
+	}
+	return &events[0], nil
+}
+
+// ListEvents 分页获取可见的活动，未结束的活动按开始时间正序，已结束的活动按开始时间倒序
+func (s *EventService) ListEvents(viewerID int64, filter EventFilter, page, pageSize int) ([]models.Event, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+
+	query := s.db.Model(&models.Event{})
+	if filter.GroupID != nil {
+		if err := requireGroupMember(s.db, *filter.GroupID, viewerID); err != nil {
+			return nil, 0, err
+		}
+		query = query.Where("group_id = ?", *filter.GroupID)
+	} else {
+		groups := s.db.Model(&models.GroupMember{}).Select("group_id").Where("user_id = ?", viewerID)
+		query = query.Where("group_id IS NULL OR group_id IN (?)", groups)
+	}
+	if filter.Joined {
+		joined := s.db.Model(&models.EventRSVP{}).Select("event_id").
+			Where("user_id = ? AND status IN ?", viewerID, []string{models.RSVPGoing, models.RSVPMaybe, models.RSVPWaitlisted})
+		query = query.Where("id IN (?)", joined)
+	}
+
+	order := "start_time ASC, id ASC"
+	if filter.Past {
+		query = query.Where("end_time < ?", time.Now())
+		order = "start_time DESC, id DESC"
+	} else {
+		query = query.Where("end_time >= ?", time.Now())
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	events := make([]models.Event, 0)
+	if err := query.Preload("Organizer").Preload("SportType").
+		Order(order).
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.fillMyRSVP(viewerID, events); err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// fillMyRSVP 批量填充当前用户的报名状态
+func (s *EventService) fillMyRSVP(viewerID int64, events []models.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+
+	var rsvps []models.EventRSVP
+	if err := s.db.Where("user_id = ? AND event_id IN ?", viewerID, ids).Find(&rsvps).Error; err != nil {
+		return err
+	}
+
+	mine := make(map[uint64]*models.EventRSVP, len(rsvps))
+	for i := range rsvps {
+		mine[rsvps[i].EventID] = &rsvps[i]
+	}
+	for i := range events {
+		events[i].MyRSVP = mine[events[i].ID]
+	}
+	return nil
+}
+
+// RSVP 报名活动。名额已满时报名参加会进入候补；
+// 参加的用户改为其他状态后，候补用户按进入候补的先后转为参加
+func (s *EventService) RSVP(userID int64, id uint64, status string) (*models.EventRSVP, error) {
+	switch status {
+	case models.RSVPGoing, models.RSVPMaybe, models.RSVPDeclined:
+	default:
+		return nil, fmt.Errorf("%w: 报名状态只能是 going/maybe/declined", ErrInvalidEvent)
+	}
+
+	var rsvp models.EventRSVP
+	var queue eventQueue
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		event, err := lockVisibleEvent(tx, userID, id)
+		if err != nil {
+			return err
+		}
+		if event.Status == models.EventStatusCanceled {
+			return fmt.Errorf("%w: 活动已取消", ErrInvalidEvent)
+		}
+		if !event.StartTime.After(time.Now()) {
+			return fmt.Errorf("%w: 活动已开始，不能再修改报名", ErrInvalidEvent)
+		}
+
+		err = tx.Where("event_id = ? AND user_id = ?", id, userID).First(&rsvp).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		exists := err == nil
+		previous := rsvp.Status
+
+		// 已参加或候补中的用户再次报名参加时保持原状态
+		if status == models.RSVPGoing && (previous == models.RSVPGoing || previous == models.RSVPWaitlisted) {
+			return nil
+		}
+
+		rsvp.EventID = id
+		rsvp.UserID = userID
+		rsvp.Status = status
+		rsvp.WaitlistedAt = nil
+		if status == models.RSVPGoing && event.Capacity > 0 && event.GoingCount >= event.Capacity {
+			now := time.Now()
+			rsvp.Status = models.RSVPWaitlisted
+			rsvp.WaitlistedAt = &now
+		}
+		if exists {
+			err = tx.Model(&rsvp).Updates(map[string]interface{}{
+				"status":        rsvp.Status,
+				"waitlisted_at": rsvp.WaitlistedAt,
+			}).Error
+		} else {
+			err = tx.Omit("User").Create(&rsvp).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		// 让出名额后由候补补上
+		if previous == models.RSVPGoing {
+			if err := promoteWaitlist(tx, &queue, event); err != nil {
+				return err
+			}
+		}
+		return recountRSVPs(tx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	queue.publish(s.db)
+	return &rsvp, nil
+}
+
+// promoteWaitlist 在名额未满时将候补用户按先后转为参加，并通知转正的用户
+func promoteWaitlist(tx *gorm.DB, queue *eventQueue, event *models.Event) error {
+	if event.Status == models.EventStatusCanceled {
+		return nil
+	}
+
+	query := tx.Where("event_id = ? AND status = ?", event.ID, models.RSVPWaitlisted).
+		Order("waitlisted_at ASC, user_id ASC")
+	if event.Capacity > 0 {
+		var going int64
+		if err := tx.Model(&models.EventRSVP{}).
+			Where("event_id = ? AND status = ?", event.ID, models.RSVPGoing).
+			Count(&going).Error; err != nil {
+			return err
+		}
+		open := int64(event.Capacity) - going
+		if open <= 0 {
+			return nil
+		}
+		query = query.Limit(int(open))
+	}
+
+	var promoted []models.EventRSVP
+	if err := query.Find(&promoted).Error; err != nil {
+		return err
+	}
+	for _, r := range promoted {
+		if err := tx.Model(&models.EventRSVP{}).
+			Where("event_id = ? AND user_id = ?", r.EventID, r.UserID).
+			Updates(map[string]interface{}{"status": models.RSVPGoing, "waitlisted_at": nil}).Error; err != nil {
+			return err
+		}
+		if err := notify(tx, queue, notifyInput{
+			UserID:     r.UserID,
+			Type:       models.NotificationTypeEventPromoted,
+			TargetType: models.NotificationTargetEvent,
+			TargetID:   event.ID,
+			Content:    event.Title,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recountRSVPs 重新统计活动的报名人数
+func recountRSVPs(tx *gorm.DB, eventID uint64) error {
+	var rows []struct {
+		Status string
+		Total  int
+	}
+	if err := tx.Model(&models.EventRSVP{}).
+		Select("status, COUNT(*) as total").
+		Where("event_id = ?", eventID).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Total
+	}
+	return tx.Model(&models.Event{}).Where("id = ?", eventID).Updates(map[string]interface{}{
+		"going_count":    counts[models.RSVPGoing],
+		"maybe_count":    counts[models.RSVPMaybe],
+		"waitlist_count": counts[models.RSVPWaitlisted],
+	}).Error
+}
+
+// GetAttendees 分页获取活动报名列表，status 为空时返回参加的用户，候补按先后排列
+func (s *EventService) GetAttendees(viewerID int64, id uint64, status string, page, pageSize int) ([]models.EventRSVP, int64, error) {
+	if _, err := findVisibleEvent(s.db, viewerID, id); err != nil {
+		return nil, 0, err
+	}
+	if status == "" {
+		status = models.RSVPGoing
+	}
+
+	page, pageSize = normalizePage(page, pageSize)
+	query := s.db.Model(&models.EventRSVP{}).Where("event_id = ? AND status = ?", id, status)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "updated_at ASC, user_id ASC"
+	if status == models.RSVPWaitlisted {
+		order = "waitlisted_at ASC, user_id ASC"
+	}
+	rsvps := make([]models.EventRSVP, 0)
+	if err := query.Preload("User").
+		Order(order).
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&rsvps).Error; err != nil {
+		return nil, 0, err
+	}
+	return rsvps, total, nil
+}
+
+// MarkAttendance 组织者确认参加用户的到场情况，活动开始后才能确认
+// 取消到场时同时解除关联的运动记录
+func (s *EventService) MarkAttendance(userID int64, id uint64, entries []AttendanceInput) error {
+	if len(entries) == 0 {
+		return fmt.Errorf("%w: 签到列表不能为空", ErrInvalidEvent)
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		event, err := findVisibleEvent(tx, userID, id)
+		if err != nil {
+			return err
+		}
+		if err := requireEventManager(tx, userID, event); err != nil {
+			return err
+		}
+		if event.Status == models.EventStatusCanceled {
+			return fmt.Errorf("%w: 活动已取消", ErrInvalidEvent)
+		}
+		if event.StartTime.After(time.Now()) {
+			return fmt.Errorf("%w: 活动开始后才能签到", ErrInvalidEvent)
+		}
+
+		now := time.Now()
+		for _, entry := range entries {
+			updates := map[string]interface{}{"attended": true, "checked_in_at": now}
+			if !entry.Attended {
+				updates = map[string]interface{}{"attended": false, "checked_in_at": nil, "sport_record_id": nil}
+			}
+			result := tx.Model(&models.EventRSVP{}).
+				Where("event_id = ? AND user_id = ? AND status = ?", id, entry.UserID, models.RSVPGoing).
+				Updates(updates)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("%w: 用户 %d 未报名参加该活动", ErrInvalidEvent, entry.UserID)
+			}
+		}
+		return nil
+	})
+}
+
+// LinkRecord 将自己的运动记录关联到已确认到场的活动，每条记录只能关联一个活动
+func (s *EventService) LinkRecord(userID int64, id uint64, recordID int64) (*models.EventRSVP, error) {
+	var rsvp models.EventRSVP
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		event, err := findVisibleEvent(tx, userID, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("event_id = ? AND user_id = ?", id, userID).First(&rsvp).Error; err != nil {
+			return err
+		}
+		if !rsvp.Attended {
+			return fmt.Errorf("%w: 组织者确认到场后才能关联运动记录", ErrInvalidEvent)
+		}
+
+		var record models.SportRecord
+		if err := tx.Select("id", "user_id", "start_time").
+			Where("id = ? AND user_id = ?", recordID, userID).
+			First(&record).Error; err != nil {
+			return err
+		}
+		if record.StartTime.Before(event.StartTime.Add(-eventRecordWindow)) ||
+			record.StartTime.After(event.EndTime.Add(eventRecordWindow)) {
+			return fmt.Errorf("%w: 运动记录的时间与活动时间不符", ErrInvalidEvent)
+		}
+
+		var count int64
+		if err := tx.Model(&models.EventRSVP{}).
+			Where("sport_record_id = ? AND event_id <> ?", recordID, id).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: 该运动记录已关联其他活动", ErrInvalidEvent)
+		}
+
+		rsvp.SportRecordID = &record.ID
+		return tx.Model(&rsvp).Update("sport_record_id", record.ID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rsvp, nil
+}
+
+// UnlinkRecord 解除活动关联的运动记录，活动不可见、未报名或未关联记录时返回记录不存在
+func (s *EventService) UnlinkRecord(userID int64, id uint64) error {
+	if _, err := findVisibleEvent(s.db, userID, id); err != nil {
+		return err
+	}
+	result := s.db.Model(&models.EventRSVP{}).
+		Where("event_id = ? AND user_id = ? AND sport_record_id IS NOT NULL", id, userID).
+		Update("sport_record_id", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sports-app/backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidFollow 关注参数校验失败
+var ErrInvalidFollow = errors.New("无效的关注")
+
+// FollowService 用户关注服务
+type FollowService struct {
+	db *gorm.DB
+}
+
+// NewFollowService 创建关注服务实例
+func NewFollowService(db *gorm.DB) *FollowService {
+	return &FollowService{db: db}
+}
+
+// Follow 关注用户，重复关注不报错
+func (s *FollowService) Follow(followerID, followeeID int64) error {
+	if followerID == followeeID {
+		return fmt.Errorf("%w: 不能关注自己", ErrInvalidFollow)
+	}
+
+	var followee models.PublicUser
+	if err := s.db.Select("id").First(&followee, followeeID).Error; err != nil {
+		return err
+	}
+	blocked, err := isBlocked(s.db, followerID, followeeID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return fmt.Errorf("%w: 无法关注该用户", ErrForbidden)
+	}
+
+	var queue eventQueue
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// 依赖唯一索引 uk_follower_followee 去重
+		follow := &models.Follow{FollowerID: followerID, FolloweeID: followeeID}
+		result := tx.Omit("Follower", "Followee").
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(follow)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return notify(tx, &queue, notifyInput{
+			UserID:     followeeID,
+			ActorID:    followerID,
+			Type:       models.NotificationTypeFollow,
+			TargetType: models.NotificationTargetUser,
+			TargetID:   uint64(followeeID),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	queue.publish(s.db)
+	return nil
+}
+
+// Unfollow 取消关注，未关注时直接返回
+func (s *FollowService) Unfollow(followerID, followeeID int64) error {
+	return s.db.Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+		Delete(&models.Follow{}).Error
+}
+
+// IsFollowing 判断是否已关注
+func (s *FollowService) IsFollowing(followerID, followeeID int64) (bool, error) {
+	var count int64
+	if err := s.db.Model(&models.Follow{}).
+		Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetCounts 获取用户的粉丝数与关注数
+func (s *FollowService) GetCounts(userID int64) (*models.FollowCounts, error) {
+	var counts models.FollowCounts
+	if err := s.db.Model(&models.Follow{}).Where("followee_id = ?", userID).
+		Count(&counts.FollowerCount).Error; err != nil {
+		return nil, err
+	}
+	if err := s.db.Model(&models.Follow{}).Where("follower_id = ?", userID).
+		Count(&counts.FollowingCount).Error; err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
+
+// GetFollowers 分页获取粉丝列表，按关注时间倒序，对方资料不可见时返回无权访问
+// 列表不包含与当前用户存在屏蔽关系的用户
+func (s *FollowService) GetFollowers(viewerID, userID int64, page, pageSize int) ([]models.Follow, int64, error) {
+	if err := s.requireVisibleProfile(viewerID, userID); err != nil {
+		return nil, 0, err
+	}
+	query := excludeBlocked(s.db.Model(&models.Follow{}).Where("followee_id = ?", userID), "follows.follower_id", viewerID)
+	return s.paginate(query.Preload("Follower"), page, pageSize)
+}
+
+// GetFollowing 分页获取关注列表，按关注时间倒序，对方资料不可见时返回无权访问
+// 列表不包含与当前用户存在屏蔽关系的用户
+func (s *FollowService) GetFollowing(viewerID, userID int64, page, pageSize int) ([]models.Follow, int64, error) {
+	if err := s.requireVisibleProfile(viewerID, userID); err != nil {
+		return nil, 0, err
+	}
+	query := excludeBlocked(s.db.Model(&models.Follow{}).Where("follower_id = ?", userID), "follows.followee_id", viewerID)
+	return s.paginate(query.Preload("Followee"), page, pageSize)
+}
+
+// requireVisibleProfile 校验用户资料对 viewer 可见，被对方屏蔽时视为用户不存在
+func (s *FollowService) requireVisibleProfile(viewerID, userID int64) error {
+	if err := s.checkBlockedBy(viewerID, userID); err != nil {
+		return err
+	}
+	visible, err := visibleProfiles(s.db, viewerID, []int64{userID})
+	if err != nil {
+		return err
+	}
+	if _, ok := visible[userID]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	if !visible[userID] {
+		return fmt.Errorf("%w: 对方设置了资料可见范围", ErrForbidden)
+	}
+	return nil
+}
+
+// paginate 分页查询关注关系
+func (s *FollowService) paginate(query *gorm.DB, page, pageSize int) ([]models.Follow, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	follows := make([]models.Follow, 0)
+	if err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&follows).Error; err != nil {
+		return nil, 0, err
+	}
+	return follows, total, nil
+}
+
+// checkBlockedBy 被对方屏蔽时视为用户不存在
+func (s *FollowService) checkBlockedBy(viewerID, userID int64) error {
+	var count int64
+	if err := s.db.Model(&models.UserBlock{}).
+		Where("blocker_id = ? AND blocked_id = ?", userID, viewerID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetUserProfile 获取用户公开资料、关注数据及当前用户是否已关注、屏蔽或静音
+// 资料不在可见范围内时只返回用户名，关注数据为0；被对方屏蔽时视为用户不存在
+func (s *FollowService) GetUserProfile(viewerID, userID int64) (*models.UserProfile, error) {
+	var user models.PublicUser
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+	if err := s.checkBlockedBy(viewerID, userID); err != nil {
+		return nil, err
+	}
+
+	profile := &models.UserProfile{PublicUser: user}
+	if viewerID != userID {
+		var err error
+		if profile.IsFollowing, err = s.IsFollowing(viewerID, userID); err != nil {
+			return nil, err
+		}
+		var blocks, mutes int64
+		if err := s.db.Model(&models.UserBlock{}).
+			Where("blocker_id = ? AND blocked_id = ?", viewerID, userID).
+			Count(&blocks).Error; err != nil {
+			return nil, err
+		}
+		if err := s.db.Model(&models.UserMute{}).
+			Where("muter_id = ? AND muted_id = ?", viewerID, userID).
+			Count(&mutes).Error; err != nil {
+			return nil, err
+		}
+		profile.IsBlocking = blocks > 0
+		profile.IsMuting = mutes > 0
+	}
+
+	visible, err := visibleProfiles(s.db, viewerID, []int64{userID})
+	if err != nil {
+		return nil, err
+	}
+	if !visible[userID] {
+		profile.ProfileHidden = true
+		return profile, nil
+	}
+
+	counts, err := s.GetCounts(userID)
+	if err != nil {
+		return nil, err
+	}
+	profile.FollowCounts = *counts
+	return profile, nil
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sports-app/backend/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GearRetirementWarnRatio 使用量达到退役阈值的该比例时提示即将退役
+const GearRetirementWarnRatio = 0.8
+
+// ErrInvalidGear 装备参数校验失败
+var ErrInvalidGear = errors.New("无效的装备")
+
+// GearService 运动装备服务
+type GearService struct {
+	db *gorm.DB
+}
+
+// NewGearService 创建运动装备服务实例
+func NewGearService(db *gorm.DB) *GearService {
+	return &GearService{db: db}
+}
+
+// gearUsage 装备累计使用量
+type gearUsage struct {
+	GearID        int64
+	TotalDistance float64
+	TotalDuration int64
+	RecordCount   int64
+}
+
+// GetGear 获取用户的装备列表及累计使用量
+func (s *GearService) GetGear(userID int64, includeRetired bool) ([]models.Gear, error) {
+	query := s.db.Where("user_id = ?", userID)
+	if !includeRetired {
+		query = query.Where("retired_at IS NULL")
+	}
+
+	var gear []models.Gear
+	if err := query.Order("created_at DESC").Find(&gear).Error; err != nil {
+		return nil, err
+	}
+	if len(gear) == 0 {
+		return gear, nil
+	}
+
+	var usages []gearUsage
+	if err := s.db.Model(&models.SportRecord{}).
+		Select("gear_id, COALESCE(SUM(distance), 0) as total_distance, COALESCE(SUM(duration), 0) as total_duration, COUNT(*) as record_count").
+		Where("user_id = ? AND gear_id IS NOT NULL", userID).
+		Group("gear_id").
+		Scan(&usages).Error; err != nil {
+		return nil, err
+	}
+
+	byGear := make(map[int64]gearUsage, len(usages))
+	for _, u := range usages {
+		byGear[u.GearID] = u
+	}
+	for i := range gear {
+		applyGearUsage(&gear[i], byGear[gear[i].ID])
+	}
+	return gear, nil
+}
+
+// GetGearByID 获取单件装备及累计使用量
+func (s *GearService) GetGearByID(userID, id int64) (*models.Gear, error) {
+	var gear models.Gear
+	if err := s.db.Where("id = ? AND user_id = ?", id, userID).First(&gear).Error; err != nil {
+		return nil, err
+	}
+
+	var usage gearUsage
+	if err := s.db.Model(&models.SportRecord{}).
+		Select("COALESCE(SUM(distance), 0) as total_distance, COALESCE(SUM(duration), 0) as total_duration, COUNT(*) as record_count").
+		Where("user_id = ? AND gear_id = ?", userID, id).
+		Scan(&usage).Error; err != nil {
+		return nil, err
+	}
+	applyGearUsage(&gear, usage)
+	return &gear, nil
+}
+
+// CreateGear 创建装备
+func (s *GearService) CreateGear(gear *models.Gear) error {
+	if err := validateGear(gear); err != nil {
+		return err
+	}
+	gear.ID = 0
+	return s.db.Create(gear).Error
+}
+
+// UpdateGear 更新装备
+func (s *GearService) UpdateGear(gear *models.Gear) error {
+	if err := validateGear(gear); err != nil {
+		return err
+	}
+	result := s.db.Model(&models.Gear{}).
+		Where("id = ? AND user_id = ?", gear.ID, gear.UserID).
+		Updates(map[string]interface{}{
+			"name":                gear.Name,
+			"type":                gear.Type,
+			"brand":               gear.Brand,
+			"purchase_date":       gear.PurchaseDate,
+			"retirement_distance": gear.RetirementDistance,
+			"retirement_duration": gear.RetirementDuration,
+			"retired_at":          gear.RetiredAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteGear 删除装备，并解除运动记录与该装备的关联
+func (s *GearService) DeleteGear(userID, id int64) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&models.Gear{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Model(&models.SportRecord{}).
+			Where("user_id = ? AND gear_id = ?", userID, id).
+			Update("gear_id", nil).Error
+	})
+}
+
+// GetGearStats 获取单件装备的统计信息
+func (s *GearService) GetGearStats(userID, id int64) (*models.GearStats, error) {
+	gear, err := s.GetGearByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.GearStats{Gear: *gear}
+	if gear.RecordCount == 0 {
+		stats.Monthly = []models.GearMonthlyUsage{}
+		return stats, nil
+	}
+	stats.AverageDistance = gear.TotalDistance / float64(gear.RecordCount)
+
+	var usedAt struct {
+		FirstUsedAt *time.Time
+		LastUsedAt  *time.Time
+	}
+	if err := s.db.Model(&models.SportRecord{}).
+		Select("MIN(start_time) as first_used_at, MAX(start_time) as last_used_at").
+		Where("user_id = ? AND gear_id = ?", userID, id).
+		Scan(&usedAt).Error; err != nil {
+		return nil, err
+	}
+	stats.FirstUsedAt = usedAt.FirstUsedAt
+	stats.LastUsedAt = usedAt.LastUsedAt
+
+	if err := s.db.Model(&models.SportRecord{}).
+		Select("DATE_FORMAT(start_time, '%Y-%m') as month, COALESCE(SUM(distance), 0) as distance, COALESCE(SUM(duration), 0) as duration, COUNT(*) as count").
+		Where("user_id = ? AND gear_id = ?", userID, id).
+		Group("month").
+		Order("month ASC").
+		Scan(&stats.Monthly).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// applyGearUsage 填充装备累计使用量并判断是否接近退役
+func applyGearUsage(gear *models.Gear, usage gearUsage) {
+	gear.TotalDistance = usage.TotalDistance
+	gear.TotalDuration = usage.TotalDuration
+	gear.RecordCount = usage.RecordCount
+
+	gear.UsageRatio = 0
+	if gear.RetirementDistance > 0 {
+		gear.UsageRatio = gear.TotalDistance / gear.RetirementDistance
+	}
+	if gear.RetirementDuration > 0 {
+		if ratio := float64(gear.TotalDuration) / float64(gear.RetirementDuration); ratio > gear.UsageRatio {
+			gear.UsageRatio = ratio
+		}
+	}
+	gear.NearRetirement = gear.RetiredAt == nil && gear.UsageRatio >= GearRetirementWarnRatio
+}
+
+// checkGear 确认运动记录关联的装备属于当前用户
+func checkGear(tx *gorm.DB, userID int64, gearID *int64) error {
+	if gearID == nil {
+		return nil
+	}
+	var count int64
+	if err := tx.Model(&models.Gear{}).Where("id = ? AND user_id = ?", *gearID, userID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: 装备不存在", ErrInvalidRecord)
+	}
+	return nil
+}
+
+// validateGear 校验装备
+func validateGear(gear *models.Gear) error {
+	gear.Name = strings.TrimSpace(gear.Name)
+	if gear.Name == "" {
+		return fmt.Errorf("%w: 装备名称不能为空", ErrInvalidGear)
+	}
+
+	switch gear.Type {
+	case models.GearTypeShoes, models.GearTypeBike, models.GearTypeRacket, models.GearTypeOther:
+	case "":
+		gear.Type = models.GearTypeOther
+	default:
+		return fmt.Errorf("%w: 不支持的装备类型 %s", ErrInvalidGear, gear.Type)
+	}
+
+	if gear.RetirementDistance < 0 || gear.RetirementDuration < 0 {
+		return fmt.Errorf("%w: 退役阈值不能为负数", ErrInvalidGear)
+	}
+	if gear.PurchaseDate != nil && gear.PurchaseDate.After(time.Now()) {
+		return fmt.Errorf("%w: 购买日期不能晚于今天", ErrInvalidGear)
+	}
+	return nil
+}
@@ -0,0 +1,671 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"sports-app/backend/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 群组内容限制
+const (
+	maxGroupNameLength        = 50
+	maxGroupDescriptionLength = 500
+	maxGroupRequestMessage    = 200
+)
+
+// ErrInvalidGroup 群组参数校验失败
+var ErrInvalidGroup = errors.New("无效的群组")
+
+// GroupInput 创建或修改群组的参数
+type GroupInput struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	AvatarURL   string `json:"avatar_url"`
+	JoinMode    string `json:"join_mode"`
+}
+
+// GroupStatsFilter 群组统计的时间范围：week/month/year，为空时统计全部
+type GroupStatsFilter struct {
+	TimeRange string `form:"time_range"`
+}
+
+// GroupService 群组服务
+type GroupService struct {
+	db        *gorm.DB
+	community *CommunityService
+}
+
+// NewGroupService 创建群组服务实例
+func NewGroupService(db *gorm.DB) *GroupService {
+	return &GroupService{db: db, community: NewCommunityService(db)}
+}
+
+// validateGroupInput 校验并规范群组参数
+func validateGroupInput(input *GroupInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Description = strings.TrimSpace(input.Description)
+	input.AvatarURL = strings.TrimSpace(input.AvatarURL)
+
+	if input.Name == "" {
+		return fmt.Errorf("%w: 名称不能为空", ErrInvalidGroup)
+	}
+	if len([]rune(input.Name)) > maxGroupNameLength {
+		return fmt.Errorf("%w: 名称不能超过%d个字符", ErrInvalidGroup, maxGroupNameLength)
+	}
+	if len([]rune(input.Description)) > maxGroupDescriptionLength {
+		return fmt.Errorf("%w: 简介不能超过%d个字符", ErrInvalidGroup, maxGroupDescriptionLength)
+	}
+	switch input.JoinMode {
+	case "":
+		input.JoinMode = models.GroupJoinOpen
+	case models.GroupJoinOpen, models.GroupJoinApproval, models.GroupJoinInvite:
+	default:
+		return fmt.Errorf("%w: 加入方式只能是 open/approval/invite", ErrInvalidGroup)
+	}
+
+	// 群组资料对所有人公开且不进入人工审核，review 策略按 reject 处理
+	for _, text := range []*string{&input.Name, &input.Description} {
+		moderation, err := GetModerator().Check(ModerationFieldGroup, *text)
+		if err != nil {
+			return err
+		}
+		if moderation.NeedsReview {
+			return fmt.Errorf("%w，请修改后重试", ErrSensitiveContent)
+		}
+		*text = moderation.Text
+	}
+	return nil
+}
+
+// newInviteCode 生成邀请码
+func newInviteCode() (string, error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.EncodeToString(buf), nil
+}
+
+// groupRole 获取用户在群组中的角色，未加入时返回空字符串
+func groupRole(db *gorm.DB, groupID uint64, userID int64) (string, error) {
+	var member models.GroupMember
+	err := db.Select("role").Where("group_id = ? AND user_id = ?", groupID, userID).First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	return member.Role, err
+}
+
+// isGroupManager 判断角色是否可以管理群组
+func isGroupManager(role string) bool {
+	return role == models.GroupRoleOwner || role == models.GroupRoleAdmin
+}
+
+// requireGroupManager 要求用户为群主或管理员
+func requireGroupManager(db *gorm.DB, groupID uint64, userID int64) (string, error) {
+	role, err := groupRole(db, groupID, userID)
+	if err != nil {
+		return "", err
+	}
+	if !isGroupManager(role) {
+		return "", fmt.Errorf("%w: 仅群主和管理员可以操作", ErrForbidden)
+	}
+	return role, nil
+}
+
+// requireGroupMember 要求用户为群组成员
+func requireGroupMember(db *gorm.DB, groupID uint64, userID int64) error {
+	role, err := groupRole(db, groupID, userID)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		return fmt.Errorf("%w: 仅群组成员可以查看", ErrForbidden)
+	}
+	return nil
+}
+
+// CreateGroup 创建群组，创建者成为群主
+func (s *GroupService) CreateGroup(userID int64, input GroupInput) (*models.Group, error) {
+	if err := validateGroupInput(&input); err != nil {
+		return nil, err
+	}
+	code, err := newInviteCode()
+	if err != nil {
+		return nil, err
+	}
+
+	group := &models.Group{
+		Name:        input.Name,
+		Description: input.Description,
+		AvatarURL:   input.AvatarURL,
+		OwnerID:     userID,
+		JoinMode:    input.JoinMode,
+		InviteCode:  code,
+		MemberCount: 1,
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Owner").Create(group).Error; err != nil {
+			return err
+		}
+		return tx.Omit("User").Create(&models.GroupMember{
+			GroupID: group.ID,
+			UserID:  userID,
+			Role:    models.GroupRoleOwner,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetGroup(userID, group.ID)
+}
+
+// UpdateGroup 修改群组资料，仅群主和管理员可以修改
+func (s *GroupService) UpdateGroup(userID int64, groupID uint64, input GroupInput) (*models.Group, error) {
+	if err := validateGroupInput(&input); err != nil {
+		return nil, err
+	}
+	if _, err := requireGroupManager(s.db, groupID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Model(&models.Group{}).Where("id = ?", groupID).Updates(map[string]interface{}{
+		"name":        input.Name,
+		"description": input.Description,
+		"avatar_url":  input.AvatarURL,
+		"join_mode":   input.JoinMode,
+	}).Error; err != nil {
+		return nil, err
+	}
+	return s.GetGroup(userID, groupID)
+}
+
+// DeleteGroup 解散群组，仅群主可以解散
+func (s *GroupService) DeleteGroup(userID int64, groupID uint64) error {
+	role, err := groupRole(s.db, groupID, userID)
+	if err != nil {
+		return err
+	}
+	if role != models.GroupRoleOwner {
+		return fmt.Errorf("%w: 仅群主可以解散群组", ErrForbidden)
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ?", groupID).Delete(&models.GroupJoinRequest{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("group_id = ?", groupID).Delete(&models.GroupMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Group{}, groupID).Error
+	})
+}
+
+// GetGroup 获取群组详情，邀请码仅群主和管理员可见
+func (s *GroupService) GetGroup(viewerID int64, groupID uint64) (*models.Group, error) {
+	var group models.Group
+	if err := s.db.Preload("Owner").First(&group, groupID).Error; err != nil {
+		return nil, err
+	}
+
+	groups := []models.Group{group}
+	if err := s.fillRoles(viewerID, groups); err != nil {
+		return nil, err
+	}
+	return &groups[0], nil
+}
+
+// escapeLike 转义 LIKE 查询中的通配符
+func escapeLike(keyword string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(keyword)
+}
+
+// ListGroups 分页搜索群组，按成员数倒序
+func (s *GroupService) ListGroups(viewerID int64, keyword string, page, pageSize int) ([]models.Group, int64, error) {
+	query := s.db.Model(&models.Group{})
+	if keyword = strings.TrimSpace(keyword); keyword != "" {
+		query = query.Where("name LIKE ?", "%"+escapeLike(keyword)+"%")
+	}
+	return s.paginate(viewerID, query, "member_count DESC, id DESC", page, pageSize)
+}
+
+// GetMyGroups 分页获取用户加入的群组，按加入时间倒序
+func (s *GroupService) GetMyGroups(userID int64, page, pageSize int) ([]models.Group, int64, error) {
+	query := s.db.Model(&models.Group{}).
+		Joins("JOIN group_members ON group_members.group_id = sport_groups.id AND group_members.user_id = ?", userID)
+	return s.paginate(userID, query, "group_members.created_at DESC, sport_groups.id DESC", page, pageSize)
+}
+
+// paginate 分页查询群组并填充当前用户的角色
+func (s *GroupService) paginate(viewerID int64, query *gorm.DB, order string, page, pageSize int) ([]models.Group, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	groups := make([]models.Group, 0)
+	if err := query.Preload("Owner").
+		Order(order).
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&groups).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.fillRoles(viewerID, groups); err != nil {
+		return nil, 0, err
+	}
+	return groups, total, nil
+}
+
+// fillRoles 批量填充当前用户在群组中的角色，并隐藏无权查看的邀请码
+func (s *GroupService) fillRoles(viewerID int64, groups []models.Group) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, len(groups))
+	for i, g := range groups {
+		ids[i] = g.ID
+	}
+
+	var members []models.GroupMember
+	if err := s.db.Select("group_id", "role").
+		Where("user_id = ? AND group_id IN ?", viewerID, ids).
+		Find(&members).Error; err != nil {
+		return err
+	}
+
+	roles := make(map[uint64]string, len(members))
+	for _, m := range members {
+		roles[m.GroupID] = m.Role
+	}
+	for i := range groups {
+		groups[i].MyRole = roles[groups[i].ID]
+		if !isGroupManager(groups[i].MyRole) {
+			groups[i].InviteCode = ""
+		}
+	}
+	return nil
+}
+
+// addGroupMember 在事务中加入成员并更新成员数，已是成员时返回 false
+func addGroupMember(tx *gorm.DB, groupID uint64, userID int64) (bool, error) {
+	result := tx.Omit("User").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.GroupMember{GroupID: groupID, UserID: userID, Role: models.GroupRoleMember})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+	if err := tx.Model(&models.Group{}).Where("id = ?", groupID).
+		UpdateColumn("member_count", gorm.Expr("member_count + 1")).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// JoinGroup 加入群组
+// 开放群组直接加入；需审批的群组提交申请，返回的 joined 为 false；仅邀请的群组需要使用邀请码
+func (s *GroupService) JoinGroup(userID int64, groupID uint64, message string) (bool, error) {
+	message = strings.TrimSpace(message)
+	if len([]rune(message)) > maxGroupRequestMessage {
+		return false, fmt.Errorf("%w: 申请留言不能超过%d个字符", ErrInvalidGroup, maxGroupRequestMessage)
+	}
+
+	var group models.Group
+	if err := s.db.Select("id", "join_mode").First(&group, groupID).Error; err != nil {
+		return false, err
+	}
+
+	role, err := groupRole(s.db, groupID, userID)
+	if err != nil {
+		return false, err
+	}
+	if role != "" {
+		return true, nil
+	}
+
+	switch group.JoinMode {
+	case models.GroupJoinOpen:
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			_, err := addGroupMember(tx, groupID, userID)
+			return err
+		})
+		return err == nil, err
+	case models.GroupJoinApproval:
+		// 依赖唯一索引 uk_group_request_user，重复申请时重新进入待审批
+		request := &models.GroupJoinRequest{GroupID: groupID, UserID: userID, Message: message, Status: models.GroupRequestPending}
+		err := s.db.Omit("User").Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"message":    message,
+				"status":     models.GroupRequestPending,
+				"handled_by": nil,
+				"handled_at": nil,
+				"updated_at": time.Now(),
+			}),
+		}).Create(request).Error
+		return false, err
+	default:
+		return false, fmt.Errorf("%w: 该群组仅能通过邀请码加入", ErrForbidden)
+	}
+}
+
+// JoinByInviteCode 通过邀请码加入群组，不受加入方式限制
+func (s *GroupService) JoinByInviteCode(userID int64, code string) (*models.Group, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return nil, fmt.Errorf("%w: 邀请码不能为空", ErrInvalidGroup)
+	}
+
+	var group models.Group
+	if err := s.db.Select("id").Where("invite_code = ?", code).First(&group).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: 邀请码无效", ErrInvalidGroup)
+		}
+		return nil, err
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		joined, err := addGroupMember(tx, group.ID, userID)
+		if err != nil || !joined {
+			return err
+		}
+		// 已有的待审批申请视为通过
+		return tx.Model(&models.GroupJoinRequest{}).
+			Where("group_id = ? AND user_id = ? AND status = ?", group.ID, userID, models.GroupRequestPending).
+			Update("status", models.GroupRequestApproved).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetGroup(userID, group.ID)
+}
+
+// LeaveGroup 退出群组，群主需要先转让群组
+func (s *GroupService) LeaveGroup(userID int64, groupID uint64) error {
+	role, err := groupRole(s.db, groupID, userID)
+	if err != nil || role == "" {
+		return err
+	}
+	if role == models.GroupRoleOwner {
+		return fmt.Errorf("%w: 群主需要先转让群组才能退出", ErrInvalidGroup)
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return removeGroupMember(tx, groupID, userID)
+	})
+}
+
+// removeGroupMember 在事务中移除成员并更新成员数
+func removeGroupMember(tx *gorm.DB, groupID uint64, userID int64) error {
+	result := tx.Where("group_id = ? AND user_id = ?", groupID, userID).Delete(&models.GroupMember{})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+	return tx.Model(&models.Group{}).Where("id = ? AND member_count > 0", groupID).
+		UpdateColumn("member_count", gorm.Expr("member_count - 1")).Error
+}
+
+// GetMembers 分页获取群组成员，群主和管理员排在前面
+func (s *GroupService) GetMembers(viewerID int64, groupID uint64, page, pageSize int) ([]models.GroupMember, int64, error) {
+	if err := s.db.Select("id").First(&models.Group{}, groupID).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page, pageSize = normalizePage(page, pageSize)
+	query := s.db.Model(&models.GroupMember{}).Where("group_id = ?", groupID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	members := make([]models.GroupMember, 0)
+	if err := query.Preload("User").
+		Order(clause.Expr{
+			SQL:  "FIELD(role, ?, ?, ?), created_at ASC",
+			Vars: []interface{}{models.GroupRoleOwner, models.GroupRoleAdmin, models.GroupRoleMember},
+		}).
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&members).Error; err != nil {
+		return nil, 0, err
+	}
+	return members, total, nil
+}
+
+// UpdateMemberRole 修改成员角色，仅群主可以操作
+// role 为 owner 时转让群组，原群主成为管理员
+func (s *GroupService) UpdateMemberRole(actorID int64, groupID uint64, userID int64, role string) error {
+	switch role {
+	case models.GroupRoleOwner, models.GroupRoleAdmin, models.GroupRoleMember:
+	default:
+		return fmt.Errorf("%w: 角色只能是 owner/admin/member", ErrInvalidGroup)
+	}
+	if actorID == userID {
+		return fmt.Errorf("%w: 不能修改自己的角色", ErrInvalidGroup)
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var group models.Group
+		// 锁定群组，避免并发转让
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "owner_id").First(&group, groupID).Error; err != nil {
+			return err
+		}
+		if group.OwnerID != actorID {
+			return fmt.Errorf("%w: 仅群主可以修改成员角色", ErrForbidden)
+		}
+
+		target, err := groupRole(tx, groupID, userID)
+		if err != nil {
+			return err
+		}
+		if target == "" {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Model(&models.GroupMember{}).
+			Where("group_id = ? AND user_id = ?", groupID, userID).
+			Update("role", role).Error; err != nil {
+			return err
+		}
+		if role != models.GroupRoleOwner {
+			return nil
+		}
+		if err := tx.Model(&models.GroupMember{}).
+			Where("group_id = ? AND user_id = ?", groupID, actorID).
+			Update("role", models.GroupRoleAdmin).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Group{}).Where("id = ?", groupID).Update("owner_id", userID).Error
+	})
+}
+
+// RemoveMember 移除成员，管理员只能移除普通成员，群主可以移除管理员
+func (s *GroupService) RemoveMember(actorID int64, groupID uint64, userID int64) error {
+	if actorID == userID {
+		return fmt.Errorf("%w: 请使用退出群组", ErrInvalidGroup)
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		actorRole, err := requireGroupManager(tx, groupID, actorID)
+		if err != nil {
+			return err
+		}
+		target, err := groupRole(tx, groupID, userID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case target == "":
+			return gorm.ErrRecordNotFound
+		case target == models.GroupRoleOwner,
+			target == models.GroupRoleAdmin && actorRole != models.GroupRoleOwner:
+			return fmt.Errorf("%w: 无权移除该成员", ErrForbidden)
+		}
+		return removeGroupMember(tx, groupID, userID)
+	})
+}
+
+// ResetInviteCode 重新生成邀请码，原邀请码立即失效
+func (s *GroupService) ResetInviteCode(userID int64, groupID uint64) (string, error) {
+	if _, err := requireGroupManager(s.db, groupID, userID); err != nil {
+		return "", err
+	}
+	code, err := newInviteCode()
+	if err != nil {
+		return "", err
+	}
+	if err := s.db.Model(&models.Group{}).Where("id = ?", groupID).
+		Update("invite_code", code).Error; err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// GetJoinRequests 分页获取入群申请，status 为空时返回待审批的申请
+func (s *GroupService) GetJoinRequests(userID int64, groupID uint64, status string, page, pageSize int) ([]models.GroupJoinRequest, int64, error) {
+	if _, err := requireGroupManager(s.db, groupID, userID); err != nil {
+		return nil, 0, err
+	}
+	if status == "" {
+		status = models.GroupRequestPending
+	}
+
+	page, pageSize = normalizePage(page, pageSize)
+	query := s.db.Model(&models.GroupJoinRequest{}).Where("group_id = ? AND status = ?", groupID, status)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	requests := make([]models.GroupJoinRequest, 0)
+	if err := query.Preload("User").
+		Order("updated_at ASC, id ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&requests).Error; err != nil {
+		return nil, 0, err
+	}
+	return requests, total, nil
+}
+
+// HandleJoinRequest 审批入群申请
+func (s *GroupService) HandleJoinRequest(userID int64, groupID, requestID uint64, approve bool) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := requireGroupManager(tx, groupID, userID); err != nil {
+			return err
+		}
+
+		var request models.GroupJoinRequest
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND group_id = ?", requestID, groupID).
+			First(&request).Error; err != nil {
+			return err
+		}
+		if request.Status != models.GroupRequestPending {
+			return fmt.Errorf("%w: 该申请已处理", ErrInvalidGroup)
+		}
+
+		status := models.GroupRequestRejected
+		if approve {
+			status = models.GroupRequestApproved
+			if _, err := addGroupMember(tx, groupID, request.UserID); err != nil {
+				return err
+			}
+		}
+		now := time.Now()
+		return tx.Model(&request).Updates(map[string]interface{}{
+			"status":     status,
+			"handled_by": userID,
+			"handled_at": now,
+		}).Error
+	})
+}
+
+// GetGroupFeed 获取群组成员对当前用户可见的打卡动态，不包含静音用户，仅成员可见
+func (s *GroupService) GetGroupFeed(viewerID int64, groupID uint64, page, pageSize int) ([]models.CheckIn, int64, error) {
+	if err := requireGroupMember(s.db, groupID, viewerID); err != nil {
+		return nil, 0, err
+	}
+
+	members := s.db.Model(&models.GroupMember{}).Select("user_id").Where("group_id = ?", groupID)
+	query := sharedCheckIns(s.db.Model(&models.CheckIn{}).Where("check_ins.user_id IN (?)", members), viewerID)
+	query = excludeMuted(query, "check_ins.user_id", viewerID)
+	return s.community.paginate(viewerID, query, page, pageSize)
+}
+
+// GetGroupStats 汇总群组成员的运动记录，仅成员可见
+// 只汇总公开的记录，避免在成员较少的群组中通过总量推算出他人未公开的记录
+func (s *GroupService) GetGroupStats(viewerID int64, groupID uint64, filter GroupStatsFilter) (*models.GroupStats, error) {
+	if err := requireGroupMember(s.db, groupID, viewerID); err != nil {
+		return nil, err
+	}
+
+	var group models.Group
+	if err := s.db.Select("id", "member_count").First(&group, groupID).Error; err != nil {
+		return nil, err
+	}
+
+	base := func() *gorm.DB {
+		query := s.db.Model(&models.SportRecord{}).
+			Joins("JOIN group_members ON group_members.user_id = sport_records.user_id AND group_members.group_id = ?", groupID).
+			Where("sport_records.visibility = ?", models.VisibilityPublic)
+		now := time.Now()
+		switch filter.TimeRange {
+		case "week":
+			query = query.Where("sport_records.start_time >= ?", now.AddDate(0, 0, -7))
+		case "month":
+			query = query.Where("sport_records.start_time >= ?", now.AddDate(0, -1, 0))
+		case "year":
+			query = query.Where("sport_records.start_time >= ?", now.AddDate(-1, 0, 0))
+		}
+		return query
+	}
+
+	stats := &models.GroupStats{MemberCount: group.MemberCount}
+	var totals struct {
+		ActiveMembers int64
+		RecordCount   int64
+		TotalDuration int64
+		TotalDistance float64
+		TotalCalories int64
+	}
+	if err := base().Select("COUNT(DISTINCT sport_records.user_id) as active_members, " +
+		"COUNT(*) as record_count, " +
+		"COALESCE(SUM(sport_records.duration), 0) as total_duration, " +
+		"COALESCE(SUM(sport_records.distance), 0) as total_distance, " +
+		"COALESCE(SUM(sport_records.calories), 0) as total_calories").
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	stats.ActiveMembers = totals.ActiveMembers
+	stats.RecordCount = totals.RecordCount
+	stats.TotalDuration = totals.TotalDuration
+	stats.TotalDistance = totals.TotalDistance
+	stats.TotalCalories = totals.TotalCalories
+
+	stats.SportTypes = make([]models.GroupSportTypeStats, 0)
+	if err := base().
+		Select("sport_records.sport_type_id, sport_types.name, " +
+			"COUNT(*) as record_count, " +
+			"COALESCE(SUM(sport_records.duration), 0) as total_duration, " +
+			"COALESCE(SUM(sport_records.distance), 0) as total_distance").
+		Joins("JOIN sport_types ON sport_types.id = sport_records.sport_type_id").
+		Group("sport_records.sport_type_id, sport_types.name").
+		Order("total_duration DESC").
+		Scan(&stats.SportTypes).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sports-app/backend/models"
+	"sports-app/backend/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 热门话题限制
+const (
+	defaultTrendingLimit = 20
+	maxTrendingLimit     = 50
+)
+
+// ErrInvalidHashtag 话题参数校验失败
+var ErrInvalidHashtag = errors.New("无效的话题")
+
+// trendingWindows 热门话题的统计窗口
+var trendingWindows = map[string]time.Duration{
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 30 * 24 * time.Hour,
+}
+
+// saveHashtags 保存话题，返回话题ID；已存在的话题依赖唯一索引去重
+func saveHashtags(tx *gorm.DB, names []string) ([]uint64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	tags := make([]models.Hashtag, len(names))
+	for i, name := range names {
+		tags[i] = models.Hashtag{Name: name}
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	var ids []uint64
+	if err := tx.Model(&models.Hashtag{}).Where("name IN ?", names).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// saveCheckInHashtags 解析打卡描述中的话题并关联到打卡
+func saveCheckInHashtags(tx *gorm.DB, checkInID uint64, text string) error {
+	ids, err := saveHashtags(tx, utils.ParseHashtags(text))
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+	links := make([]models.CheckInHashtag, len(ids))
+	for i, id := range ids {
+		links[i] = models.CheckInHashtag{CheckInID: checkInID, HashtagID: id}
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+}
+
+// saveCommentHashtags 解析评论内容中的话题并关联到评论
+// 编辑评论时只删除不再出现的话题、补充新增的话题，保留的关联不变，热门话题统计使用的关联时间不受编辑影响
+func saveCommentHashtags(tx *gorm.DB, commentID uint64, text string) error {
+	ids, err := saveHashtags(tx, utils.ParseHashtags(text))
+	if err != nil {
+		return err
+	}
+	removed := tx.Where("comment_id = ?", commentID)
+	if len(ids) > 0 {
+		removed = removed.Where("hashtag_id NOT IN ?", ids)
+	}
+	if err := removed.Delete(&models.CommentHashtag{}).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	links := make([]models.CommentHashtag, len(ids))
+	for i, id := range ids {
+		links[i] = models.CommentHashtag{CommentID: commentID, HashtagID: id}
+	}
+	// 已存在的关联依赖主键跳过，保留原有的关联时间
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+}
+
+// resolveMentions 解析文本中提及的用户ID
+// 中文内容中用户名后可能紧跟正文，每个候选取与已有用户名匹配的最长前缀
+func resolveMentions(db *gorm.DB, text string) ([]int64, error) {
+	candidates := utils.ParseMentions(text)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	prefixes := make([]string, 0)
+	for _, candidate := range candidates {
+		prefixes = append(prefixes, utils.MentionPrefixes(candidate)...)
+	}
+	var users []models.PublicUser
+	if err := db.Select("id", "username").Where("username IN ?", prefixes).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	// 数据库按不区分大小写的排序规则匹配用户名，这里同样忽略大小写
+	byName := make(map[string]int64, len(users))
+	for _, user := range users {
+		byName[strings.ToLower(user.Username)] = user.ID
+	}
+
+	ids := make([]int64, 0, len(candidates))
+	seen := make(map[int64]bool)
+	for _, candidate := range candidates {
+		for _, prefix := range utils.MentionPrefixes(candidate) {
+			if id, ok := byName[strings.ToLower(prefix)]; ok {
+				if !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+				break
+			}
+		}
+	}
+	return ids, nil
+}
+
+// mentionInput 提及通知的参数
+type mentionInput struct {
+	ActorID    int64
+	CheckInID  uint64 // 内容所在的打卡，被提及的用户需要能看到该打卡
+	TargetType string
+	TargetID   uint64
+	Text       string
+	Skip       map[int64]bool // 不再通知的用户，如编辑前已提及或已收到评论、回复通知的用户
+}
+
+// notifyMentions 在调用方的事务中通知内容中提及的用户
+// 与触发者存在屏蔽关系或看不到该打卡的用户不通知
+func notifyMentions(tx *gorm.DB, queue *eventQueue, in mentionInput) error {
+	ids, err := resolveMentions(tx, in.Text)
+	if err != nil {
+		return err
+	}
+	for _, userID := range ids {
+		if userID == in.ActorID || in.Skip[userID] {
+			continue
+		}
+		blocked, err := isBlocked(tx, in.ActorID, userID)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			continue
+		}
+		var visible int64
+		if err := visibleCheckIns(tx.Model(&models.CheckIn{}).Where("check_ins.id = ?", in.CheckInID), userID).
+			Count(&visible).Error; err != nil {
+			return err
+		}
+		if visible == 0 {
+			continue
+		}
+
+		if err := notify(tx, queue, notifyInput{
+			UserID:     userID,
+			ActorID:    in.ActorID,
+			Type:       models.NotificationTypeMention,
+			TargetType: in.TargetType,
+			TargetID:   in.TargetID,
+			Content:    in.Text,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HashtagService 话题服务
+type HashtagService struct {
+	db        *gorm.DB
+	community *CommunityService
+}
+
+// NewHashtagService 创建话题服务实例
+func NewHashtagService(db *gorm.DB) *HashtagService {
+	return &HashtagService{db: db, community: NewCommunityService(db)}
+}
+
+// GetTagFeed 获取包含话题的打卡动态，只返回对当前用户可见的打卡，不包含静音用户
+func (s *HashtagService) GetTagFeed(viewerID int64, name string, page, pageSize int) ([]models.CheckIn, int64, error) {
+	normalized, ok := utils.NormalizeHashtag(name)
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: 话题只能包含文字、数字和下划线，且不超过%d个字符", ErrInvalidHashtag, utils.MaxHashtagLength)
+	}
+
+	var tag models.Hashtag
+	if err := s.db.Where("name = ?", normalized).First(&tag).Error; err != nil {
+		return nil, 0, err
+	}
+
+	tagged := s.db.Model(&models.CheckInHashtag{}).Select("check_in_id").Where("hashtag_id = ?", tag.ID)
+	query := sharedCheckIns(s.db.Model(&models.CheckIn{}).Where("check_ins.id IN (?)", tagged), viewerID)
+	query = excludeMuted(query, "check_ins.user_id", viewerID)
+	return s.community.paginate(viewerID, query, page, pageSize)
+}
+
+// GetTrendingTags 获取统计窗口内使用次数最多的话题
+// 只统计公开且未隐藏的打卡，以及这些打卡下未隐藏的评论
+func (s *HashtagService) GetTrendingTags(window string, limit int) ([]models.TrendingHashtag, error) {
+	if window == "" {
+		window = "day"
+	}
+	duration, ok := trendingWindows[window]
+	if !ok {
+		return nil, fmt.Errorf("%w: 统计窗口只能是 day/week/month", ErrInvalidHashtag)
+	}
+	if limit <= 0 {
+		limit = defaultTrendingLimit
+	}
+	if limit > maxTrendingLimit {
+		limit = maxTrendingLimit
+	}
+	since := time.Now().Add(-duration)
+
+	tags := make([]models.TrendingHashtag, 0)
+	// 打卡和评论分别按 created_at 索引取窗口内的话题，合并后计数
+	err := s.db.Raw("SELECT hashtags.id, hashtags.name, COUNT(*) AS use_count FROM ("+
+		"SELECT h.hashtag_id FROM check_in_hashtags h JOIN check_ins c ON c.id = h.check_in_id "+
+		"WHERE h.created_at >= ? AND c.visibility = ? AND c.is_hidden = ? AND c.deleted_at IS NULL "+
+		"UNION ALL "+
+		"SELECT h.hashtag_id FROM comment_hashtags h JOIN comments m ON m.id = h.comment_id "+
+		"JOIN check_ins c ON c.id = m.check_in_id "+
+		"WHERE h.created_at >= ? AND m.is_hidden = ? AND m.deleted_at IS NULL "+
+		"AND c.visibility = ? AND c.is_hidden = ? AND c.deleted_at IS NULL"+
+		") uses JOIN hashtags ON hashtags.id = uses.hashtag_id "+
+		"GROUP BY hashtags.id, hashtags.name ORDER BY use_count DESC, hashtags.id DESC LIMIT ?",
+		since, models.VisibilityPublic, false,
+		since, false, models.VisibilityPublic, false,
+		limit).Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
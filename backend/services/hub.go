@@ -0,0 +1,218 @@
+package services
+
+import (
+	"log"
+	"sports-app/backend/models"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// 推送事件类型
+const (
+	EventNotification = "notification" // 新通知或通知合并更新
+	EventLike         = "like"         // 打卡被点赞
+	EventComment      = "comment"      // 打卡收到评论
+	EventMessage      = "message"      // 新私信
+	EventMessageRead  = "message_read" // 对方已读私信
+)
+
+// 推送中心参数
+const (
+	hubHistorySize   = 1000 // 保留最近的事件用于断线续传
+	subscriberBuffer = 32   // 每个连接的待发送事件缓冲
+)
+
+// Event 推送给客户端的事件，ID 在进程内单调递增
+type Event struct {
+	ID     uint64      `json:"id"`
+	UserID int64       `json:"-"`
+	Type   string      `json:"type"`
+	Data   interface{} `json:"data"`
+}
+
+// Resume 订阅时需要补发的事件
+type Resume struct {
+	Events []Event // Last-Event-ID 之后错过的事件
+	Reset  bool    // 错过的事件已超出保留范围（或服务已重启），客户端需要重新拉取
+	LastID uint64  // 订阅时最新的事件ID
+}
+
+// Subscription 单个连接的订阅
+type Subscription struct {
+	UserID    int64
+	SessionID uint64 // 连接所属的登录会话，会话吊销时断开
+	Events    <-chan Event
+
+	events chan Event
+	hub    *Hub
+}
+
+// Close 取消订阅
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// Hub 进程内的发布/订阅中心，按用户分发事件，可在多个协程中并发使用
+type Hub struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []Event
+	subscribers map[int64]map[*Subscription]struct{}
+}
+
+// NewHub 创建推送中心
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[int64]map[*Subscription]struct{})}
+}
+
+var (
+	defaultHub     *Hub
+	defaultHubOnce sync.Once
+)
+
+// GetHub 获取全局推送中心
+func GetHub() *Hub {
+	defaultHubOnce.Do(func() {
+		defaultHub = NewHub()
+	})
+	return defaultHub
+}
+
+// Publish 向用户的所有连接推送事件
+// 连接缓冲已满时直接断开该连接，客户端重连后通过 Last-Event-ID 补发
+func (h *Hub) Publish(userID int64, eventType string, data interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	event := Event{ID: h.lastID, UserID: userID, Type: eventType, Data: data}
+	h.history = append(h.history, event)
+	if len(h.history) > hubHistorySize {
+		h.history = h.history[len(h.history)-hubHistorySize:]
+	}
+
+	for sub := range h.subscribers[userID] {
+		select {
+		case sub.events <- event:
+		default:
+			h.remove(sub)
+		}
+	}
+}
+
+// Subscribe 订阅用户的事件，lastEventID 大于0时返回之后错过的事件
+func (h *Hub) Subscribe(userID int64, sessionID uint64, lastEventID uint64) (*Subscription, Resume) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	events := make(chan Event, subscriberBuffer)
+	sub := &Subscription{UserID: userID, SessionID: sessionID, Events: events, events: events, hub: h}
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[userID][sub] = struct{}{}
+
+	resume := Resume{LastID: h.lastID}
+	if lastEventID == 0 {
+		return sub, resume
+	}
+	if lastEventID > h.lastID || (len(h.history) > 0 && h.history[0].ID > lastEventID+1) {
+		resume.Reset = true
+		return sub, resume
+	}
+	for _, event := range h.history {
+		if event.ID > lastEventID && event.UserID == userID {
+			resume.Events = append(resume.Events, event)
+		}
+	}
+	return sub, resume
+}
+
+// DisconnectSession 断开会话的所有连接，会话吊销后调用
+func (h *Hub) DisconnectSession(userID int64, sessionID uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers[userID] {
+		if sub.SessionID == sessionID {
+			h.remove(sub)
+		}
+	}
+}
+
+// DisconnectUser 断开用户的所有连接，用户的全部会话吊销后调用
+func (h *Hub) DisconnectUser(userID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers[userID] {
+		h.remove(sub)
+	}
+}
+
+// unsubscribe 取消订阅
+func (h *Hub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(sub)
+}
+
+// remove 移除订阅并关闭其事件通道，调用方需持有锁
+func (h *Hub) remove(sub *Subscription) {
+	subs, ok := h.subscribers[sub.UserID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	close(sub.events)
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.UserID)
+	}
+}
+
+// eventQueue 收集事务中产生的推送，事务提交后再统一发布，避免推送回滚的数据
+type eventQueue struct {
+	events        []Event
+	notifications []uint64
+}
+
+// add 加入待发布事件
+func (q *eventQueue) add(userID int64, eventType string, data interface{}) {
+	q.events = append(q.events, Event{UserID: userID, Type: eventType, Data: data})
+}
+
+// addNotification 加入待推送的通知
+func (q *eventQueue) addNotification(id uint64) {
+	q.notifications = append(q.notifications, id)
+}
+
+// publish 发布事件，通知在发布前重新加载以带上触发者和未读数
+func (q *eventQueue) publish(db *gorm.DB) {
+	hub := GetHub()
+	for _, event := range q.events {
+		hub.Publish(event.UserID, event.Type, event.Data)
+	}
+
+	for _, id := range q.notifications {
+		var notification models.Notification
+		if err := db.Preload("Actor").First(&notification, id).Error; err != nil {
+			log.Printf("加载待推送通知 %d 失败: %v", id, err)
+			continue
+		}
+		notification.Summary = notificationSummary(&notification)
+
+		var unread int64
+		if err := db.Model(&models.Notification{}).
+			Where("user_id = ? AND is_read = ?", notification.UserID, false).
+			Count(&unread).Error; err != nil {
+			log.Printf("统计未读通知失败: %v", err)
+			continue
+		}
+		hub.Publish(notification.UserID, EventNotification, map[string]interface{}{
+			"notification": notification,
+			"unread_count": unread,
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"sports-app/backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LikeState 点赞操作后的状态
+type LikeState struct {
+	Liked     bool  `json:"liked"`
+	LikeCount int64 `json:"like_count"`
+}
+
+// LikeService 点赞服务
+type LikeService struct {
+	db *gorm.DB
+}
+
+// NewLikeService 创建点赞服务实例
+func NewLikeService(db *gorm.DB) *LikeService {
+	return &LikeService{db: db}
+}
+
+// Like 点赞打卡，重复点赞不报错也不重复计数
+func (s *LikeService) Like(userID, checkInID int64) (*LikeState, error) {
+	var state *LikeState
+	var queue eventQueue
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		checkIn, err := findVisibleCheckIn(tx, userID, checkInID)
+		if err != nil {
+			return err
+		}
+
+		like := &models.Like{UserID: uint64(userID), CheckInID: uint64(checkInID)}
+		// 依赖唯一索引 uk_user_check_in 去重，并发请求只有一条能插入成功
+		result := tx.Omit("User", "CheckIn").
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(like)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			if err := tx.Model(&models.CheckIn{}).Where("id = ?", checkInID).
+				UpdateColumn("like_count", gorm.Expr("like_count + 1")).Error; err != nil {
+				return err
+			}
+			if err := notify(tx, &queue, notifyInput{
+				UserID:     int64(checkIn.UserID),
+				ActorID:    userID,
+				Type:       models.NotificationTypeLike,
+				TargetType: models.NotificationTargetCheckIn,
+				TargetID:   checkIn.ID,
+			}); err != nil {
+				return err
+			}
+		}
+
+		state, err = s.loadState(tx, checkInID, true)
+		if err == nil && result.RowsAffected > 0 && int64(checkIn.UserID) != userID {
+			queue.add(int64(checkIn.UserID), EventLike, map[string]interface{}{
+				"check_in_id": checkIn.ID,
+				"user_id":     userID,
+				"like_count":  state.LikeCount,
+			})
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	queue.publish(s.db)
+	return state, nil
+}
+
+// Unlike 取消点赞，未点赞时直接返回当前状态
+func (s *LikeService) Unlike(userID, checkInID int64) (*LikeState, error) {
+	var state *LikeState
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := findVisibleCheckIn(tx, userID, checkInID); err != nil {
+			return err
+		}
+
+		// 物理删除，避免软删除记录占用唯一索引导致无法再次点赞
+		result := tx.Unscoped().
+			Where("user_id = ? AND check_in_id = ?", userID, checkInID).
+			Delete(&models.Like{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			if err := tx.Model(&models.CheckIn{}).Where("id = ? AND like_count > 0", checkInID).
+				UpdateColumn("like_count", gorm.Expr("like_count - 1")).Error; err != nil {
+				return err
+			}
+		}
+
+		var err error
+		state, err = s.loadState(tx, checkInID, false)
+		return err
+	})
+	return state, err
+}
+
+// ListLikes 分页获取打卡的点赞列表，按点赞时间倒序，不包含与当前用户存在屏蔽关系的用户
+func (s *LikeService) ListLikes(viewerID, checkInID int64, page, pageSize int) ([]models.Like, int64, error) {
+	if _, err := findVisibleCheckIn(s.db, viewerID, checkInID); err != nil {
+		return nil, 0, err
+	}
+
+	page, pageSize = normalizePage(page, pageSize)
+	query := excludeBlocked(s.db.Model(&models.Like{}).Where("check_in_id = ?", checkInID), "likes.user_id", viewerID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var likes []models.Like
+	if err := query.Preload("User").
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&likes).Error; err != nil {
+		return nil, 0, err
+	}
+	return likes, total, nil
+}
+
+// loadState 读取打卡最新点赞数
+func (s *LikeService) loadState(tx *gorm.DB, checkInID int64, liked bool) (*LikeState, error) {
+	var checkIn models.CheckIn
+	if err := tx.Select("id", "like_count").First(&checkIn, checkInID).Error; err != nil {
+		return nil, err
+	}
+	return &LikeState{Liked: liked, LikeCount: checkIn.LikeCount}, nil
+}
@@ -0,0 +1,423 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sports-app/backend/models"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxMessageLength 单条私信最大字数
+const maxMessageLength = 1000
+
+// ErrInvalidMessage 私信参数校验失败
+var ErrInvalidMessage = errors.New("无效的私信")
+
+// MessageService 私信服务
+type MessageService struct {
+	db *gorm.DB
+}
+
+// NewMessageService 创建私信服务实例
+func NewMessageService(db *gorm.DB) *MessageService {
+	return &MessageService{db: db}
+}
+
+// OpenConversation 获取与某个用户的会话，不存在时创建
+func (s *MessageService) OpenConversation(userID, peerID int64) (*models.ConversationMember, error) {
+	if userID == peerID {
+		return nil, fmt.Errorf("%w: 不能给自己发私信", ErrInvalidMessage)
+	}
+
+	var peer models.PublicUser
+	if err := s.db.Select("id").First(&peer, peerID).Error; err != nil {
+		return nil, err
+	}
+
+	var conversationID uint64
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		conversation, err := findConversationBetween(tx, userID, peerID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanMessage(tx, userID, peerID, conversation.ID); err != nil {
+			return err
+		}
+		conversationID = conversation.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetConversation(userID, conversationID)
+}
+
+// findConversationBetween 查找两个用户之间的会话，不存在时创建会话及双方的会话状态
+func findConversationBetween(tx *gorm.DB, userID, peerID int64) (*models.Conversation, error) {
+	low, high := userID, peerID
+	if low > high {
+		low, high = high, low
+	}
+
+	// 依赖唯一索引 uk_conversation_users 去重，并发创建时只有一条能插入成功
+	conversation := &models.Conversation{UserLowID: low, UserHighID: high}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(conversation).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("user_low_id = ? AND user_high_id = ?", low, high).
+		First(conversation).Error; err != nil {
+		return nil, err
+	}
+
+	members := []models.ConversationMember{
+		{ConversationID: conversation.ID, UserID: userID, PeerID: peerID},
+		{ConversationID: conversation.ID, UserID: peerID, PeerID: userID},
+	}
+	if err := tx.Omit("Peer").Clauses(clause.OnConflict{DoNothing: true}).Create(&members).Error; err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+// checkCanMessage 按屏蔽关系和接收者的私信权限判断能否发送
+// 接收者在会话中发过私信时视为同意对话，不再受权限限制；屏蔽关系始终生效
+func checkCanMessage(tx *gorm.DB, senderID, recipientID int64, conversationID uint64) error {
+	var recipient models.User
+	if err := tx.Select("id", "message_privacy").First(&recipient, recipientID).Error; err != nil {
+		return err
+	}
+
+	blocked, err := isBlocked(tx, senderID, recipientID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return fmt.Errorf("%w: 无法向该用户发送私信", ErrForbidden)
+	}
+
+	switch recipient.MessagePrivacy {
+	case models.MessagePrivacyNobody:
+	case models.MessagePrivacyFollowing:
+		var count int64
+		if err := tx.Model(&models.Follow{}).
+			Where("follower_id = ? AND followee_id = ?", recipientID, senderID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if conversationID != 0 {
+		var count int64
+		if err := tx.Model(&models.Message{}).
+			Where("conversation_id = ? AND sender_id = ?", conversationID, recipientID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: 对方设置了私信权限，暂时无法发送", ErrForbidden)
+}
+
+// SendMessage 在会话中发送私信，对方未读数加一并实时推送给双方
+func (s *MessageService) SendMessage(senderID int64, conversationID uint64, content string) (*models.Message, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, fmt.Errorf("%w: 内容不能为空", ErrInvalidMessage)
+	}
+	if len([]rune(content)) > maxMessageLength {
+		return nil, fmt.Errorf("%w: 内容不能超过%d个字符", ErrInvalidMessage, maxMessageLength)
+	}
+
+	moderation, err := GetModerator().Check(ModerationFieldMessage, content)
+	if err != nil {
+		return nil, err
+	}
+	// 私信不进入人工审核队列，review 策略按 reject 处理
+	if moderation.NeedsReview {
+		return nil, fmt.Errorf("%w，请修改后重试", ErrSensitiveContent)
+	}
+
+	var message *models.Message
+	var queue eventQueue
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		member, err := findMember(tx, senderID, conversationID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanMessage(tx, senderID, member.PeerID, conversationID); err != nil {
+			return err
+		}
+
+		message = &models.Message{
+			ConversationID: conversationID,
+			SenderID:       senderID,
+			RecipientID:    member.PeerID,
+			Content:        moderation.Text,
+		}
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Conversation{}).Where("id = ?", conversationID).
+			UpdateColumn("last_message_id", message.ID).Error; err != nil {
+			return err
+		}
+
+		// 自己发送的私信视为已读
+		if err := tx.Model(&models.ConversationMember{}).
+			Where("conversation_id = ? AND user_id = ?", conversationID, senderID).
+			Updates(map[string]interface{}{
+				"last_message_at":      message.CreatedAt,
+				"last_read_message_id": message.ID,
+			}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.ConversationMember{}).
+			Where("conversation_id = ? AND user_id = ?", conversationID, member.PeerID).
+			Updates(map[string]interface{}{
+				"last_message_at": message.CreatedAt,
+				"unread_count":    gorm.Expr("unread_count + 1"),
+			}).Error; err != nil {
+			return err
+		}
+
+		unread, err := unreadMessageCount(tx, member.PeerID)
+		if err != nil {
+			return err
+		}
+		queue.add(member.PeerID, EventMessage, map[string]interface{}{
+			"conversation_id": conversationID,
+			"message":         message,
+			"unread_count":    unread,
+		})
+		// 同步到发送者的其他设备
+		queue.add(senderID, EventMessage, map[string]interface{}{
+			"conversation_id": conversationID,
+			"message":         message,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	queue.publish(s.db)
+	return message, nil
+}
+
+// findMember 查找用户在会话中的状态，非会话参与者返回记录不存在
+func findMember(db *gorm.DB, userID int64, conversationID uint64) (*models.ConversationMember, error) {
+	var member models.ConversationMember
+	if err := db.Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// unreadMessageCount 统计用户所有会话的未读私信总数
+func unreadMessageCount(db *gorm.DB, userID int64) (int64, error) {
+	var total int64
+	err := db.Model(&models.ConversationMember{}).
+		Select("COALESCE(SUM(unread_count), 0)").
+		Where("user_id = ?", userID).
+		Scan(&total).Error
+	return total, err
+}
+
+// GetConversations 分页获取会话列表，按最新私信时间倒序，没有私信的会话不返回
+func (s *MessageService) GetConversations(userID int64, page, pageSize int) ([]models.ConversationMember, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	query := s.db.Model(&models.ConversationMember{}).
+		Where("user_id = ? AND last_message_at IS NOT NULL", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	members := make([]models.ConversationMember, 0)
+	if err := query.Preload("Peer").
+		Order("last_message_at DESC, conversation_id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&members).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.fillConversations(members); err != nil {
+		return nil, 0, err
+	}
+	return members, total, nil
+}
+
+// GetConversation 获取单个会话
+func (s *MessageService) GetConversation(userID int64, conversationID uint64) (*models.ConversationMember, error) {
+	var member models.ConversationMember
+	if err := s.db.Preload("Peer").
+		Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		First(&member).Error; err != nil {
+		return nil, err
+	}
+
+	members := []models.ConversationMember{member}
+	if err := s.fillConversations(members); err != nil {
+		return nil, err
+	}
+	return &members[0], nil
+}
+
+// fillConversations 批量填充会话的最新私信及对方的已读位置
+func (s *MessageService) fillConversations(members []models.ConversationMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, len(members))
+	for i, m := range members {
+		ids[i] = m.ConversationID
+	}
+
+	var lastIDs []uint64
+	if err := s.db.Model(&models.Conversation{}).
+		Where("id IN ? AND last_message_id > 0", ids).
+		Pluck("last_message_id", &lastIDs).Error; err != nil {
+		return err
+	}
+	var messages []models.Message
+	if len(lastIDs) > 0 {
+		if err := s.db.Where("id IN ?", lastIDs).Find(&messages).Error; err != nil {
+			return err
+		}
+	}
+
+	var peers []models.ConversationMember
+	if err := s.db.Select("conversation_id", "user_id", "last_read_message_id").
+		Where("conversation_id IN ?", ids).
+		Find(&peers).Error; err != nil {
+		return err
+	}
+
+	lastMessages := make(map[uint64]*models.Message, len(messages))
+	for i := range messages {
+		lastMessages[messages[i].ConversationID] = &messages[i]
+	}
+	peerRead := make(map[uint64]uint64, len(members))
+	for i := range members {
+		for _, p := range peers {
+			if p.ConversationID == members[i].ConversationID && p.UserID == members[i].PeerID {
+				peerRead[p.ConversationID] = p.LastReadMessageID
+			}
+		}
+	}
+	for i := range members {
+		members[i].LastMessage = lastMessages[members[i].ConversationID]
+		members[i].PeerLastReadMessageID = peerRead[members[i].ConversationID]
+	}
+	return nil
+}
+
+// GetMessages 获取会话的历史私信，按发送时间倒序游标分页
+// cursor 为上一页返回的 nextCursor（即最早一条私信的ID），为空时从最新一条开始
+func (s *MessageService) GetMessages(userID int64, conversationID uint64, cursor string, limit int) ([]models.Message, string, error) {
+	if _, err := findMember(s.db, userID, conversationID); err != nil {
+		return nil, "", err
+	}
+	_, limit = normalizePage(1, limit)
+
+	query := s.db.Where("conversation_id = ?", conversationID)
+	if cursor != "" {
+		beforeID, err := strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: 游标格式错误", ErrInvalidMessage)
+		}
+		query = query.Where("id < ?", beforeID)
+	}
+
+	messages := make([]models.Message, 0, limit+1)
+	if err := query.Order("id DESC").Limit(limit + 1).Find(&messages).Error; err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(messages) > limit {
+		messages = messages[:limit]
+		nextCursor = strconv.FormatUint(messages[limit-1].ID, 10)
+	}
+	return messages, nextCursor, nil
+}
+
+// MarkRead 将会话标记为已读，并通知对方更新已读回执
+func (s *MessageService) MarkRead(userID int64, conversationID uint64) error {
+	var queue eventQueue
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// 锁定会话行：发送私信时会更新该行，加锁后读取的最新消息与未读数一致，
+		// 避免把读取之后新到达的私信一并清零
+		var conversation models.Conversation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "last_message_id").First(&conversation, conversationID).Error; err != nil {
+			return err
+		}
+		member, err := findMember(tx, userID, conversationID)
+		if err != nil {
+			return err
+		}
+		if member.UnreadCount == 0 && member.LastReadMessageID >= conversation.LastMessageID {
+			return nil
+		}
+
+		if err := tx.Model(&models.ConversationMember{}).
+			Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+			Updates(map[string]interface{}{
+				"unread_count":         0,
+				"last_read_message_id": conversation.LastMessageID,
+			}).Error; err != nil {
+			return err
+		}
+		queue.add(member.PeerID, EventMessageRead, map[string]interface{}{
+			"conversation_id":      conversationID,
+			"user_id":              userID,
+			"last_read_message_id": conversation.LastMessageID,
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	queue.publish(s.db)
+	return nil
+}
+
+// GetUnreadCount 获取未读私信总数
+func (s *MessageService) GetUnreadCount(userID int64) (int64, error) {
+	return unreadMessageCount(s.db, userID)
+}
+
+// GetPrivacy 获取用户的私信权限
+func (s *MessageService) GetPrivacy(userID int64) (string, error) {
+	var user models.User
+	if err := s.db.Select("id", "message_privacy").First(&user, userID).Error; err != nil {
+		return "", err
+	}
+	return user.MessagePrivacy, nil
+}
+
+// UpdatePrivacy 更新用户的私信权限
+func (s *MessageService) UpdatePrivacy(userID int64, privacy string) error {
+	switch privacy {
+	case models.MessagePrivacyEveryone, models.MessagePrivacyFollowing, models.MessagePrivacyNobody:
+	default:
+		return fmt.Errorf("%w: 私信权限只能是 everyone/following/nobody", ErrInvalidMessage)
+	}
+	return s.db.Model(&models.User{}).Where("id = ?", userID).
+		UpdateColumn("message_privacy", privacy).Error
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sports-app/backend/config"
+	"sports-app/backend/models"
+	"sports-app/backend/utils"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// 内容审核策略
+const (
+	ModerationPolicyReject = "reject" // 拒绝提交
+	ModerationPolicyMask   = "mask"   // 敏感词替换为 *
+	ModerationPolicyReview = "review" // 保存但隐藏，进入人工审核
+)
+
+// 需要审核的字段
+const (
+	ModerationFieldCheckIn  = "check_in"
+	ModerationFieldComment  = "comment"
+	ModerationFieldUsername = "username"
+	ModerationFieldMessage  = "message"
+	ModerationFieldGroup    = "group"
+)
+
+// maxPinyinVariantChars 生成汉字拼音混写组合的最大字数，超出时只匹配全拼
+const maxPinyinVariantChars = 8
+
+// ErrSensitiveContent 内容包含敏感词
+var ErrSensitiveContent = errors.New("内容包含敏感词")
+
+// ModerationResult 审核结果
+type ModerationResult struct {
+	Text        string   // 处理后的文本，mask 策略下敏感词已替换
+	Matched     []string // 命中的词条
+	NeedsReview bool     // 需要人工审核
+}
+
+// moderationPattern 匹配模式对应的词条
+type moderationPattern struct {
+	word       int  // 词条下标
+	latinStart bool // 首字符为英文字母，要求前面不是英文字母
+	latinEnd   bool // 末字符为英文字母，要求后面不是英文字母
+}
+
+// Moderator 敏感词过滤器，构建后只读，可并发使用
+type Moderator struct {
+	words    []string
+	patterns []moderationPattern
+	matcher  *utils.ACMatcher
+	policies map[string]string
+}
+
+var (
+	defaultModerator     *Moderator
+	defaultModeratorOnce sync.Once
+)
+
+// GetModerator 获取全局敏感词过滤器，首次调用时按配置加载词表
+func GetModerator() *Moderator {
+	defaultModeratorOnce.Do(func() {
+		cfg := config.LoadModerationConfig()
+		policies := map[string]string{
+			ModerationFieldCheckIn:  cfg.CheckInPolicy,
+			ModerationFieldComment:  cfg.CommentPolicy,
+			ModerationFieldUsername: cfg.UsernamePolicy,
+			ModerationFieldMessage:  cfg.MessagePolicy,
+			ModerationFieldGroup:    cfg.GroupPolicy,
+		}
+
+		file, err := os.Open(cfg.WordListPath)
+		if err != nil {
+			log.Printf("加载敏感词表失败，将不过滤内容: %v", err)
+			defaultModerator = NewModerator(nil, policies)
+			return
+		}
+		defer file.Close()
+
+		entries, err := ParseWordList(file)
+		if err != nil {
+			log.Printf("解析敏感词表失败，将不过滤内容: %v", err)
+		}
+		defaultModerator = NewModerator(entries, policies)
+		log.Printf("已加载敏感词 %d 条", len(entries))
+	})
+	return defaultModerator
+}
+
+// ParseWordList 解析敏感词表，每行一个词条，词条后可用 | 追加拼音或其他变体
+func ParseWordList(r io.Reader) ([][]string, error) {
+	var entries [][]string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var entry []string
+		for _, part := range strings.Split(line, "|") {
+			if part = strings.TrimSpace(part); part != "" {
+				entry = append(entry, part)
+			}
+		}
+		if len(entry) > 0 {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// NewModerator 根据词条构建过滤器，每个词条的第一项为词语，其余为变体
+func NewModerator(entries [][]string, policies map[string]string) *Moderator {
+	m := &Moderator{policies: policies}
+	seen := make(map[string]bool)
+	var patterns [][]rune
+
+	add := func(word int, text string) {
+		pattern, _ := utils.NormalizeText(text)
+		if len(pattern) == 0 || seen[string(pattern)] {
+			return
+		}
+		seen[string(pattern)] = true
+		patterns = append(patterns, pattern)
+		m.patterns = append(m.patterns, moderationPattern{
+			word:       word,
+			latinStart: isLatinLetter(pattern[0]),
+			latinEnd:   isLatinLetter(pattern[len(pattern)-1]),
+		})
+	}
+
+	for _, entry := range entries {
+		word := len(m.words)
+		m.words = append(m.words, entry[0])
+		add(word, entry[0])
+
+		chars, _ := utils.NormalizeText(entry[0])
+		for _, variant := range entry[1:] {
+			syllables := strings.Fields(variant)
+			if len(syllables) != len(chars) || len(chars) > maxPinyinVariantChars {
+				add(word, variant)
+				continue
+			}
+			// 每个字可以写成汉字或拼音，生成全部组合
+			for mask := 1; mask < 1<<len(chars); mask++ {
+				var b strings.Builder
+				for i, c := range chars {
+					if mask&(1<<i) != 0 {
+						b.WriteString(syllables[i])
+					} else {
+						b.WriteRune(c)
+					}
+				}
+				add(word, b.String())
+			}
+		}
+	}
+
+	m.matcher = utils.NewACMatcher(patterns)
+	return m
+}
+
+// Check 按字段对应的策略审核文本
+func (m *Moderator) Check(field, text string) (*ModerationResult, error) {
+	result := &ModerationResult{Text: text}
+	spans, matched := m.find(text)
+	if len(spans) == 0 {
+		return result, nil
+	}
+	result.Matched = matched
+
+	switch m.policies[field] {
+	case ModerationPolicyReject:
+		return nil, fmt.Errorf("%w，请修改后重试", ErrSensitiveContent)
+	case ModerationPolicyReview:
+		// 用户名无法隐藏展示，审核期间按原文保存
+		result.NeedsReview = true
+		return result, nil
+	default:
+		runes := []rune(text)
+		for _, span := range spans {
+			for i := span[0]; i <= span[1]; i++ {
+				runes[i] = '*'
+			}
+		}
+		result.Text = string(runes)
+		return result, nil
+	}
+}
+
+// find 查找敏感词，返回命中区间（原文 rune 下标，闭区间）及命中的词条
+func (m *Moderator) find(text string) ([][2]int, []string) {
+	if len(m.patterns) == 0 {
+		return nil, nil
+	}
+	normalized, positions := utils.NormalizeText(text)
+
+	var spans [][2]int
+	var matched []string
+	hit := make(map[int]bool)
+	for _, match := range m.matcher.FindAll(normalized) {
+		p := m.patterns[match.Pattern]
+		// 纯拼音或英文变体只匹配完整单词，避免误伤普通英文
+		if p.latinStart && match.Start > 0 && isLatinLetter(normalized[match.Start-1]) {
+			continue
+		}
+		if p.latinEnd && match.End < len(normalized) && isLatinLetter(normalized[match.End]) {
+			continue
+		}
+		spans = append(spans, [2]int{positions[match.Start], positions[match.End-1]})
+		if !hit[p.word] {
+			hit[p.word] = true
+			matched = append(matched, m.words[p.word])
+		}
+	}
+	return spans, matched
+}
+
+// isLatinLetter 判断是否为英文字母（已规范化为小写）
+func isLatinLetter(r rune) bool {
+	return r >= 'a' && r <= 'z'
+}
+
+// queueReview 将命中敏感词的内容加入人工审核队列
+func queueReview(tx *gorm.DB, targetType string, targetID uint64, userID int64, content string, matched []string) error {
+	return tx.Create(&models.ModerationReview{
+		TargetType:   targetType,
+		TargetID:     targetID,
+		UserID:       userID,
+		Content:      content,
+		MatchedWords: excerpt(strings.Join(matched, ","), 200),
+		Status:       models.ReviewStatusPending,
+	}).Error
+}
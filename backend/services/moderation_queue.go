@@ -0,0 +1,514 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sports-app/backend/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 举报与封禁限制
+const (
+	maxReportDetails   = 500
+	defaultSuspendDays = 7
+	maxSuspendDays     = 365
+)
+
+// ErrInvalidReport 举报或审核操作参数校验失败
+var ErrInvalidReport = errors.New("无效的举报")
+
+// reportReasons 允许的举报原因
+var reportReasons = map[string]bool{
+	models.ReportReasonSpam:    true,
+	models.ReportReasonAbuse:   true,
+	models.ReportReasonSexual:  true,
+	models.ReportReasonIllegal: true,
+	models.ReportReasonOther:   true,
+}
+
+// CreateReportInput 举报参数
+type CreateReportInput struct {
+	TargetType string `json:"target_type" binding:"required"`
+	TargetID   uint64 `json:"target_id" binding:"required"`
+	Reason     string `json:"reason" binding:"required"`
+	Details    string `json:"details"`
+}
+
+// ModerationActionInput 管理员操作参数
+type ModerationActionInput struct {
+	Note string `json:"note"`
+	Days int    `json:"days"` // 封禁天数，仅封禁时使用
+}
+
+// ModerationQueueService 举报与管理员审核服务
+type ModerationQueueService struct {
+	db *gorm.DB
+}
+
+// NewModerationQueueService 创建审核服务实例
+func NewModerationQueueService(db *gorm.DB) *ModerationQueueService {
+	return &ModerationQueueService{db: db}
+}
+
+// CreateReport 举报打卡、评论或用户；重复举报同一对象时更新原举报并重新进入待处理
+func (s *ModerationQueueService) CreateReport(reporterID int64, input CreateReportInput) (*models.Report, error) {
+	if !reportReasons[input.Reason] {
+		return nil, fmt.Errorf("%w: 不支持的举报原因", ErrInvalidReport)
+	}
+	details := strings.TrimSpace(input.Details)
+	if len([]rune(details)) > maxReportDetails {
+		return nil, fmt.Errorf("%w: 举报说明不能超过%d个字符", ErrInvalidReport, maxReportDetails)
+	}
+
+	targetUserID, err := s.visibleTargetOwner(reporterID, input.TargetType, input.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if targetUserID == reporterID {
+		return nil, fmt.Errorf("%w: 不能举报自己", ErrInvalidReport)
+	}
+
+	report := &models.Report{
+		ReporterID:   reporterID,
+		TargetType:   input.TargetType,
+		TargetID:     input.TargetID,
+		TargetUserID: targetUserID,
+		Reason:       input.Reason,
+		Details:      details,
+		Status:       models.ReportStatusPending,
+	}
+	if err := s.db.Omit("Reporter", "TargetUser").
+		Clauses(clause.OnConflict{DoUpdates: clause.Assignments(map[string]interface{}{
+			"reason":      input.Reason,
+			"details":     details,
+			"status":      models.ReportStatusPending,
+			"action":      "",
+			"resolved_by": nil,
+			"resolved_at": nil,
+			"updated_at":  time.Now(),
+		})}).
+		Create(report).Error; err != nil {
+		return nil, err
+	}
+
+	var saved models.Report
+	if err := s.db.Where("reporter_id = ? AND target_type = ? AND target_id = ?",
+		reporterID, input.TargetType, input.TargetID).First(&saved).Error; err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// GetReports 分页获取举报，默认只返回待处理的举报，按提交时间先后排列
+func (s *ModerationQueueService) GetReports(status string, page, pageSize int) ([]models.Report, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	if status == "" {
+		status = models.ReportStatusPending
+	}
+	query := s.db.Model(&models.Report{}).Where("status = ?", status)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	reports := make([]models.Report, 0)
+	if err := query.Preload("Reporter").Preload("TargetUser").
+		Order("created_at ASC, id ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&reports).Error; err != nil {
+		return nil, 0, err
+	}
+	return reports, total, nil
+}
+
+// HandleReport 处理举报：hide 隐藏内容、dismiss 驳回、warn 警告作者、suspend 封禁作者
+// 同一对象上所有待处理的举报会一并结案
+func (s *ModerationQueueService) HandleReport(moderatorID int64, reportID uint64, action string, input ModerationActionInput) (*models.Report, error) {
+	var queue eventQueue
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var report models.Report
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&report, reportID).Error; err != nil {
+			return err
+		}
+		if report.Status != models.ReportStatusPending {
+			return fmt.Errorf("%w: 举报已处理", ErrInvalidReport)
+		}
+
+		status := models.ReportStatusResolved
+		switch action {
+		case models.ModerationActionHide:
+			if report.TargetType == models.ModerationTargetUser {
+				return fmt.Errorf("%w: 用户不能隐藏，请使用封禁", ErrInvalidReport)
+			}
+			if err := setTargetHidden(tx, report.TargetType, report.TargetID, true); err != nil {
+				return err
+			}
+		case models.ModerationActionDismiss:
+			status = models.ReportStatusDismissed
+		case models.ModerationActionWarn:
+			if err := notifySystem(tx, &queue, report.TargetUserID, withNote("你发布的内容违反社区规范，已被管理员警告", input.Note)); err != nil {
+				return err
+			}
+		case models.ModerationActionSuspend:
+			if err := suspendUser(tx, &queue, report.TargetUserID, input); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: 不支持的操作", ErrInvalidReport)
+		}
+
+		now := time.Now()
+		if err := tx.Model(&models.Report{}).
+			Where("target_type = ? AND target_id = ? AND status = ?", report.TargetType, report.TargetID, models.ReportStatusPending).
+			Updates(map[string]interface{}{
+				"status":      status,
+				"action":      action,
+				"resolved_by": moderatorID,
+				"resolved_at": now,
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&models.ModerationAction{
+			ModeratorID:  moderatorID,
+			Action:       action,
+			TargetType:   report.TargetType,
+			TargetID:     report.TargetID,
+			TargetUserID: report.TargetUserID,
+			ReportID:     &report.ID,
+			Note:         strings.TrimSpace(input.Note),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	queue.publish(s.db)
+
+	var report models.Report
+	if err := s.db.Preload("Reporter").Preload("TargetUser").First(&report, reportID).Error; err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// RestoreTarget 恢复被隐藏的打卡或评论，或解除用户封禁；对象上待审核的敏感词审核一并通过
+// 恢复的内容补发审核期间未发送的提及通知
+func (s *ModerationQueueService) RestoreTarget(moderatorID int64, targetType string, targetID uint64, input ModerationActionInput) error {
+	var queue eventQueue
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		ownerID, err := targetOwner(tx, targetType, targetID)
+		if err != nil {
+			return err
+		}
+
+		if targetType == models.ModerationTargetUser {
+			if err := tx.Model(&models.User{}).Where("id = ?", targetID).
+				Update("suspended_until", nil).Error; err != nil {
+				return err
+			}
+		} else {
+			if err := setTargetHidden(tx, targetType, targetID, false); err != nil {
+				return err
+			}
+			if err := notifyApprovedMentions(tx, &queue, targetType, targetID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.ModerationReview{}).
+			Where("target_type = ? AND target_id = ? AND status = ?", targetType, targetID, models.ReviewStatusPending).
+			Updates(map[string]interface{}{
+				"status":      models.ReviewStatusApproved,
+				"reviewer_id": moderatorID,
+				"reviewed_at": time.Now(),
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&models.ModerationAction{
+			ModeratorID:  moderatorID,
+			Action:       models.ModerationActionRestore,
+			TargetType:   targetType,
+			TargetID:     targetID,
+			TargetUserID: ownerID,
+			Note:         strings.TrimSpace(input.Note),
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	queue.publish(s.db)
+	return nil
+}
+
+// GetReviews 分页获取敏感词审核队列，默认只返回待审核的内容
+func (s *ModerationQueueService) GetReviews(status string, page, pageSize int) ([]models.ModerationReview, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	if status == "" {
+		status = models.ReviewStatusPending
+	}
+	query := s.db.Model(&models.ModerationReview{}).Where("status = ?", status)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	reviews := make([]models.ModerationReview, 0)
+	if err := query.Order("created_at ASC, id ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+// HandleReview 处理敏感词审核：通过后内容恢复可见并补发提及通知，拒绝后保持隐藏
+func (s *ModerationQueueService) HandleReview(moderatorID int64, reviewID uint64, approve bool, input ModerationActionInput) (*models.ModerationReview, error) {
+	var review models.ModerationReview
+	var queue eventQueue
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, reviewID).Error; err != nil {
+			return err
+		}
+		if review.Status != models.ReviewStatusPending {
+			return fmt.Errorf("%w: 内容已审核", ErrInvalidReport)
+		}
+
+		action := models.ModerationActionRejectReview
+		status := models.ReviewStatusRejected
+		if approve {
+			action = models.ModerationActionApproveReview
+			status = models.ReviewStatusApproved
+			if review.TargetType != models.ModerationTargetUser {
+				if err := setTargetHidden(tx, review.TargetType, review.TargetID, false); err != nil {
+					return err
+				}
+				if err := notifyApprovedMentions(tx, &queue, review.TargetType, review.TargetID); err != nil {
+					return err
+				}
+			}
+		}
+
+		now := time.Now()
+		review.Status = status
+		review.ReviewerID = &moderatorID
+		review.ReviewedAt = &now
+		if err := tx.Model(&review).Updates(map[string]interface{}{
+			"status":      status,
+			"reviewer_id": moderatorID,
+			"reviewed_at": now,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&models.ModerationAction{
+			ModeratorID:  moderatorID,
+			Action:       action,
+			TargetType:   review.TargetType,
+			TargetID:     review.TargetID,
+			TargetUserID: review.UserID,
+			ReviewID:     &review.ID,
+			Note:         strings.TrimSpace(input.Note),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	queue.publish(s.db)
+	return &review, nil
+}
+
+// GetActions 分页获取审核操作记录，可按对象过滤，按时间倒序
+func (s *ModerationQueueService) GetActions(targetType string, targetID uint64, page, pageSize int) ([]models.ModerationAction, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	query := s.db.Model(&models.ModerationAction{})
+	if targetType != "" {
+		query = query.Where("target_type = ?", targetType)
+	}
+	if targetID > 0 {
+		query = query.Where("target_id = ?", targetID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	actions := make([]models.ModerationAction, 0)
+	if err := query.Preload("Moderator").
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&actions).Error; err != nil {
+		return nil, 0, err
+	}
+	return actions, total, nil
+}
+
+// visibleTargetOwner 查找举报人可见的举报对象，返回其作者
+func (s *ModerationQueueService) visibleTargetOwner(viewerID int64, targetType string, targetID uint64) (int64, error) {
+	switch targetType {
+	case models.ModerationTargetCheckIn:
+		checkIn, err := findVisibleCheckIn(s.db, viewerID, int64(targetID))
+		if err != nil {
+			return 0, err
+		}
+		return int64(checkIn.UserID), nil
+	case models.ModerationTargetComment:
+		var comment models.Comment
+		if err := s.db.Where("id = ? AND is_deleted = ?", targetID, false).First(&comment).Error; err != nil {
+			return 0, err
+		}
+		if _, err := findVisibleCheckIn(s.db, viewerID, int64(comment.CheckInID)); err != nil {
+			return 0, err
+		}
+		return int64(comment.UserID), nil
+	case models.ModerationTargetUser:
+		return targetOwner(s.db, targetType, targetID)
+	default:
+		return 0, fmt.Errorf("%w: 不支持的举报对象", ErrInvalidReport)
+	}
+}
+
+// targetOwner 查找审核对象的作者，对象为用户时返回用户本身
+func targetOwner(db *gorm.DB, targetType string, targetID uint64) (int64, error) {
+	switch targetType {
+	case models.ModerationTargetCheckIn:
+		var checkIn models.CheckIn
+		if err := db.Select("id", "user_id").First(&checkIn, targetID).Error; err != nil {
+			return 0, err
+		}
+		return int64(checkIn.UserID), nil
+	case models.ModerationTargetComment:
+		var comment models.Comment
+		if err := db.Select("id", "user_id").First(&comment, targetID).Error; err != nil {
+			return 0, err
+		}
+		return int64(comment.UserID), nil
+	case models.ModerationTargetUser:
+		var user models.PublicUser
+		if err := db.Select("id").First(&user, targetID).Error; err != nil {
+			return 0, err
+		}
+		return user.ID, nil
+	default:
+		return 0, fmt.Errorf("%w: 不支持的对象类型", ErrInvalidReport)
+	}
+}
+
+// setTargetHidden 隐藏或恢复打卡、评论
+func setTargetHidden(tx *gorm.DB, targetType string, targetID uint64, hidden bool) error {
+	if _, err := targetOwner(tx, targetType, targetID); err != nil {
+		return err
+	}
+	switch targetType {
+	case models.ModerationTargetCheckIn:
+		return tx.Model(&models.CheckIn{}).Where("id = ?", targetID).Update("is_hidden", hidden).Error
+	case models.ModerationTargetComment:
+		return tx.Model(&models.Comment{}).Where("id = ?", targetID).Update("is_hidden", hidden).Error
+	default:
+		return fmt.Errorf("%w: 该对象不能隐藏", ErrInvalidReport)
+	}
+}
+
+// notifyApprovedMentions 审核通过或恢复打卡、评论后通知其中提及的用户
+// 参数与发布时一致：评论不再通知打卡作者和被回复者；已收到过该内容提及通知的用户不重复通知
+func notifyApprovedMentions(tx *gorm.DB, queue *eventQueue, targetType string, targetID uint64) error {
+	var in mentionInput
+	switch targetType {
+	case models.ModerationTargetCheckIn:
+		var checkIn models.CheckIn
+		if err := tx.Select("id", "user_id", "description").First(&checkIn, targetID).Error; err != nil {
+			return err
+		}
+		in = mentionInput{
+			ActorID:    int64(checkIn.UserID),
+			CheckInID:  checkIn.ID,
+			TargetType: models.NotificationTargetCheckIn,
+			TargetID:   checkIn.ID,
+			Text:       checkIn.Description,
+			Skip:       make(map[int64]bool),
+		}
+	case models.ModerationTargetComment:
+		var comment models.Comment
+		if err := tx.Preload("CheckIn", func(db *gorm.DB) *gorm.DB { return db.Select("id", "user_id") }).
+			Preload("Parent", func(db *gorm.DB) *gorm.DB { return db.Select("id", "user_id") }).
+			Select("id", "user_id", "check_in_id", "parent_id", "content", "is_deleted").
+			First(&comment, targetID).Error; err != nil {
+			return err
+		}
+		if comment.IsDeleted {
+			return nil
+		}
+		in = mentionInput{
+			ActorID:    int64(comment.UserID),
+			CheckInID:  comment.CheckInID,
+			TargetType: models.NotificationTargetComment,
+			TargetID:   comment.ID,
+			Text:       comment.Content,
+			Skip:       map[int64]bool{int64(comment.CheckIn.UserID): true},
+		}
+		if comment.Parent != nil {
+			in.Skip[int64(comment.Parent.UserID)] = true
+		}
+	default:
+		return nil
+	}
+
+	var notified []int64
+	if err := tx.Model(&models.Notification{}).
+		Where("type = ? AND target_type = ? AND target_id = ?", models.NotificationTypeMention, in.TargetType, in.TargetID).
+		Pluck("user_id", &notified).Error; err != nil {
+		return err
+	}
+	for _, userID := range notified {
+		in.Skip[userID] = true
+	}
+	return notifyMentions(tx, queue, in)
+}
+
+// suspendUser 封禁用户指定天数并发送通知
+func suspendUser(tx *gorm.DB, queue *eventQueue, userID int64, input ModerationActionInput) error {
+	days := input.Days
+	if days == 0 {
+		days = defaultSuspendDays
+	}
+	if days < 1 || days > maxSuspendDays {
+		return fmt.Errorf("%w: 封禁天数需在1到%d之间", ErrInvalidReport, maxSuspendDays)
+	}
+
+	until := time.Now().AddDate(0, 0, days)
+	if err := tx.Model(&models.User{}).Where("id = ?", userID).
+		Update("suspended_until", until).Error; err != nil {
+		return err
+	}
+	message := fmt.Sprintf("你的账号因违反社区规范被禁止发布内容至%s", until.Format("2006-01-02 15:04"))
+	return notifySystem(tx, queue, userID, withNote(message, input.Note))
+}
+
+// notifySystem 发送系统通知，内容不超过通知表的字段长度
+func notifySystem(tx *gorm.DB, queue *eventQueue, userID int64, content string) error {
+	return notify(tx, queue, notifyInput{
+		UserID:     userID,
+		Type:       models.NotificationTypeSystem,
+		TargetType: models.NotificationTargetUser,
+		TargetID:   uint64(userID),
+		Content:    excerpt(content, 250),
+	})
+}
+
+// withNote 在通知内容后附加管理员备注
+func withNote(message, note string) string {
+	if note = strings.TrimSpace(note); note != "" {
+		return message + "：" + note
+	}
+	return message
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sports-app/backend/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 通知保留策略
+const (
+	notificationReadRetention = 90 * 24 * time.Hour  // 已读通知保留90天
+	notificationRetention     = 180 * 24 * time.Hour // 未读通知最多保留180天
+	notificationPurgeBatch    = 1000
+	notificationExcerptLength = 50
+)
+
+// notifyInput 生成通知的参数
+type notifyInput struct {
+	UserID     int64 // 接收者
+	ActorID    int64 // 触发者，系统通知为0
+	Type       string
+	TargetType string
+	TargetID   uint64
+	Content    string
+}
+
+// groupedNotificationTypes 需要合并的通知类型
+var groupedNotificationTypes = map[string]bool{
+	models.NotificationTypeLike:    true,
+	models.NotificationTypeComment: true,
+	models.NotificationTypeReply:   true,
+	models.NotificationTypeFollow:  true,
+}
+
+// notify 在调用方的事务中生成通知，同一对象上未读的同类通知合并为一条
+// 生成或更新的通知加入 queue，由调用方在事务提交后推送
+func notify(tx *gorm.DB, queue *eventQueue, in notifyInput) error {
+	if in.ActorID != 0 && in.ActorID == in.UserID {
+		return nil
+	}
+	if in.Type != models.NotificationTypeSystem {
+		in.Content = excerpt(in.Content, notificationExcerptLength)
+	}
+	groupKey := fmt.Sprintf("%s:%s:%d", in.Type, in.TargetType, in.TargetID)
+
+	var actorID *int64
+	if in.ActorID != 0 {
+		actorID = &in.ActorID
+	}
+
+	if groupedNotificationTypes[in.Type] && actorID != nil {
+		var existing models.Notification
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND group_key = ? AND is_read = ?", in.UserID, groupKey, false).
+			Order("id DESC").
+			First(&existing).Error
+		if err == nil {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&models.NotificationActor{NotificationID: existing.ID, ActorID: in.ActorID})
+			if result.Error != nil {
+				return result.Error
+			}
+			updates := map[string]interface{}{
+				"actor_id":   in.ActorID,
+				"content":    in.Content,
+				"updated_at": time.Now(),
+			}
+			if result.RowsAffected > 0 {
+				updates["actor_count"] = gorm.Expr("actor_count + 1")
+			}
+			if err := tx.Model(&models.Notification{}).Where("id = ?", existing.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+			queue.addNotification(existing.ID)
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+	}
+
+	notification := &models.Notification{
+		UserID:     in.UserID,
+		Type:       in.Type,
+		TargetType: in.TargetType,
+		TargetID:   in.TargetID,
+		GroupKey:   groupKey,
+		ActorID:    actorID,
+		ActorCount: 1,
+		Content:    in.Content,
+	}
+	if err := tx.Omit("Actor").Create(notification).Error; err != nil {
+		return err
+	}
+	if actorID != nil {
+		if err := tx.Create(&models.NotificationActor{NotificationID: notification.ID, ActorID: in.ActorID}).Error; err != nil {
+			return err
+		}
+	}
+	queue.addNotification(notification.ID)
+	return nil
+}
+
+// excerpt 截取文本摘要
+func excerpt(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit]) + "…"
+}
+
+// NotificationService 站内通知服务
+type NotificationService struct {
+	db *gorm.DB
+}
+
+// NewNotificationService 创建通知服务实例
+func NewNotificationService(db *gorm.DB) *NotificationService {
+	return &NotificationService{db: db}
+}
+
+// GetNotifications 分页获取通知，按最近活动时间倒序
+func (s *NotificationService) GetNotifications(userID int64, unreadOnly bool, page, pageSize int) ([]models.Notification, int64, error) {
+	page, pageSize = normalizePage(page, pageSize)
+
+	query := s.db.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("is_read = ?", false)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	notifications := make([]models.Notification, 0)
+	if err := query.Preload("Actor").
+		Order("updated_at DESC, id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&notifications).Error; err != nil {
+		return nil, 0, err
+	}
+	for i := range notifications {
+		notifications[i].Summary = notificationSummary(&notifications[i])
+	}
+	return notifications, total, nil
+}
+
+// GetUnreadCount 获取未读通知数
+func (s *NotificationService) GetUnreadCount(userID int64) (int64, error) {
+	var count int64
+	err := s.db.Model(&models.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead 将单条通知标记为已读，重复标记不报错
+func (s *NotificationService) MarkRead(userID int64, id uint64) error {
+	var notification models.Notification
+	if err := s.db.Select("id", "is_read").
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&notification).Error; err != nil {
+		return err
+	}
+	if notification.IsRead {
+		return nil
+	}
+	return s.db.Model(&models.Notification{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"is_read": true, "read_at": time.Now()}).Error
+}
+
+// MarkAllRead 将全部未读通知标记为已读，返回标记的数量
+func (s *NotificationService) MarkAllRead(userID int64) (int64, error) {
+	result := s.db.Model(&models.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Updates(map[string]interface{}{"is_read": true, "read_at": time.Now()})
+	return result.RowsAffected, result.Error
+}
+
+// NotifyBadge 通知用户获得勋章，供成就系统调用
+func (s *NotificationService) NotifyBadge(userID int64, badgeID uint64, badgeName string) error {
+	var queue eventQueue
+	if err := notify(s.db, &queue, notifyInput{
+		UserID:     userID,
+		Type:       models.NotificationTypeBadge,
+		TargetType: models.NotificationTargetBadge,
+		TargetID:   badgeID,
+		Content:    badgeName,
+	}); err != nil {
+		return err
+	}
+	queue.publish(s.db)
+	return nil
+}
+
+// PurgeExpired 按保留策略清理过期通知，返回删除的数量
+func (s *NotificationService) PurgeExpired(now time.Time) (int64, error) {
+	var purged int64
+	for {
+		var ids []uint64
+		if err := s.db.Model(&models.Notification{}).
+			Where("(is_read = ? AND updated_at < ?) OR updated_at < ?",
+				true, now.Add(-notificationReadRetention), now.Add(-notificationRetention)).
+			Limit(notificationPurgeBatch).
+			Pluck("id", &ids).Error; err != nil {
+			return purged, err
+		}
+		if len(ids) == 0 {
+			return purged, nil
+		}
+
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("notification_id IN ?", ids).Delete(&models.NotificationActor{}).Error; err != nil {
+				return err
+			}
+			return tx.Where("id IN ?", ids).Delete(&models.Notification{}).Error
+		})
+		if err != nil {
+			return purged, err
+		}
+		purged += int64(len(ids))
+	}
+}
+
+// StartRetention 定期清理过期通知，在后台协程中运行
+func (s *NotificationService) StartRetention(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if purged, err := s.PurgeExpired(time.Now()); err != nil {
+			log.Printf("清理过期通知失败: %v", err)
+		} else if purged > 0 {
+			log.Printf("已清理过期通知 %d 条", purged)
+		}
+		<-ticker.C
+	}
+}
+
+// notificationSummary 生成通知展示文案
+func notificationSummary(n *models.Notification) string {
+	switch n.Type {
+	case models.NotificationTypeBadge:
+		return fmt.Sprintf("你获得了勋章「%s」", n.Content)
+	case models.NotificationTypeSystem:
+		return n.Content
+	case models.NotificationTypeEventPromoted:
+		return fmt.Sprintf("活动「%s」有人退出，你已从候补转为参加", n.Content)
+	case models.NotificationTypeEventCanceled:
+		return fmt.Sprintf("你报名的活动「%s」已取消", n.Content)
+	}
+
+	actor := "有人"
+	if n.Actor != nil {
+		actor = n.Actor.Username
+	}
+	if n.ActorCount > 1 {
+		actor = fmt.Sprintf("%s等%d人", actor, n.ActorCount)
+	}
+
+	switch n.Type {
+	case models.NotificationTypeLike:
+		return actor + "赞了你的打卡"
+	case models.NotificationTypeComment:
+		return actor + "评论了你的打卡"
+	case models.NotificationTypeReply:
+		return actor + "回复了你的评论"
+	case models.NotificationTypeFollow:
+		return actor + "关注了你"
+	case models.NotificationTypeMention:
+		if n.TargetType == models.NotificationTargetComment {
+			return actor + "在评论中提到了你"
+		}
+		return actor + "在打卡中提到了你"
+	default:
+		return actor + "与你互动"
+	}
+}
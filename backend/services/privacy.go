@@ -0,0 +1,147 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sports-app/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidPrivacy 隐私设置参数校验失败
+var ErrInvalidPrivacy = errors.New("无效的隐私设置")
+
+// PrivacySettings 账号隐私设置，更新时为空的字段保持不变
+type PrivacySettings struct {
+	DefaultRecordVisibility  string `json:"default_record_visibility"`
+	DefaultCheckInVisibility string `json:"default_check_in_visibility"`
+	ProfileVisibility        string `json:"profile_visibility"`
+}
+
+// validVisibility 判断可见范围是否合法，允许为空
+func validVisibility(visibility string) bool {
+	switch visibility {
+	case "", models.VisibilityPublic, models.VisibilityFollowers, models.VisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// audienceCondition 内容对非作者 viewer 可见的条件：公开，或仅关注者可见且 viewer 已关注作者
+// table 为内容所在的表，要求包含 user_id 和 visibility 字段
+func audienceCondition(db *gorm.DB, table string, viewerID int64) (string, []interface{}) {
+	followees := db.Session(&gorm.Session{NewDB: true}).Model(&models.Follow{}).
+		Select("followee_id").Where("follower_id = ?", viewerID)
+	sql := fmt.Sprintf("(%[1]s.visibility = ? OR (%[1]s.visibility = ? AND %[1]s.user_id IN (?)))", table)
+	return sql, []interface{}{models.VisibilityPublic, models.VisibilityFollowers, followees}
+}
+
+// sharedCheckIns 限定为对 viewer 展示的他人打卡：未隐藏、在可见范围内且双方没有屏蔽关系
+func sharedCheckIns(db *gorm.DB, viewerID int64) *gorm.DB {
+	cond, args := audienceCondition(db, "check_ins", viewerID)
+	db = db.Where("check_ins.is_hidden = ? AND "+cond, append([]interface{}{false}, args...)...)
+	return excludeBlocked(db, "check_ins.user_id", viewerID)
+}
+
+// visibleCheckIns 限定为 viewer 可见的打卡：本人发布的全部打卡，以及对其展示的他人打卡
+func visibleCheckIns(db *gorm.DB, viewerID int64) *gorm.DB {
+	cond, args := audienceCondition(db, "check_ins", viewerID)
+	db = db.Where("check_ins.user_id = ? OR (check_ins.is_hidden = ? AND "+cond+")",
+		append([]interface{}{viewerID, false}, args...)...)
+	return excludeBlocked(db, "check_ins.user_id", viewerID)
+}
+
+// visibleProfiles 批量判断用户资料对 viewer 是否可见，本人始终可见
+func visibleProfiles(db *gorm.DB, viewerID int64, userIDs []int64) (map[int64]bool, error) {
+	visible := make(map[int64]bool, len(userIDs))
+	if len(userIDs) == 0 {
+		return visible, nil
+	}
+
+	var users []models.User
+	if err := db.Select("id", "profile_visibility").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	var followees []int64
+	if err := db.Model(&models.Follow{}).
+		Where("follower_id = ? AND followee_id IN ?", viewerID, userIDs).
+		Pluck("followee_id", &followees).Error; err != nil {
+		return nil, err
+	}
+	following := make(map[int64]bool, len(followees))
+	for _, id := range followees {
+		following[id] = true
+	}
+
+	for _, user := range users {
+		switch {
+		case user.ID == viewerID:
+			visible[user.ID] = true
+		case user.ProfileVisibility == models.VisibilityFollowers:
+			visible[user.ID] = following[user.ID]
+		default:
+			visible[user.ID] = user.ProfileVisibility != models.VisibilityPrivate
+		}
+	}
+	return visible, nil
+}
+
+// defaultVisibility 获取用户设置的默认可见范围，column 为 users 表中的字段
+func defaultVisibility(db *gorm.DB, userID int64, column string) (string, error) {
+	var visibility string
+	if err := db.Model(&models.User{}).Where("id = ?", userID).Pluck(column, &visibility).Error; err != nil {
+		return "", err
+	}
+	if visibility == "" {
+		visibility = models.VisibilityPrivate
+	}
+	return visibility, nil
+}
+
+// PrivacyService 隐私设置服务
+type PrivacyService struct {
+	db *gorm.DB
+}
+
+// NewPrivacyService 创建隐私设置服务实例
+func NewPrivacyService(db *gorm.DB) *PrivacyService {
+	return &PrivacyService{db: db}
+}
+
+// GetSettings 获取用户的隐私设置
+func (s *PrivacyService) GetSettings(userID int64) (*PrivacySettings, error) {
+	var user models.User
+	if err := s.db.Select("id", "default_record_visibility", "default_check_in_visibility", "profile_visibility").
+		First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+	return &PrivacySettings{
+		DefaultRecordVisibility:  user.DefaultRecordVisibility,
+		DefaultCheckInVisibility: user.DefaultCheckInVisibility,
+		ProfileVisibility:        user.ProfileVisibility,
+	}, nil
+}
+
+// UpdateSettings 更新用户的隐私设置，只影响之后发布的内容
+func (s *PrivacyService) UpdateSettings(userID int64, input PrivacySettings) (*PrivacySettings, error) {
+	updates := make(map[string]interface{})
+	for column, value := range map[string]string{
+		"default_record_visibility":   input.DefaultRecordVisibility,
+		"default_check_in_visibility": input.DefaultCheckInVisibility,
+		"profile_visibility":          input.ProfileVisibility,
+	} {
+		if !validVisibility(value) {
+			return nil, fmt.Errorf("%w: 可见范围只能是 public/followers/private", ErrInvalidPrivacy)
+		}
+		if value != "" {
+			updates[column] = value
+		}
+	}
+
+	if len(updates) > 0 {
+		if err := s.db.Model(&models.User{}).Where("id = ?", userID).UpdateColumns(updates).Error; err != nil {
+			return nil, err
+		}
+	}
+	return s.GetSettings(userID)
+}
@@ -1,12 +1,27 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"sports-app/backend/models"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// ErrInvalidRecord 运动记录参数校验失败
+var ErrInvalidRecord = errors.New("无效的运动记录")
+
+// StatsFilter 运动统计筛选条件
+type StatsFilter struct {
+	TimeRange   string // week/month/year，其他值表示不限
+	SportTypeID int64
+	Environment string
+	Mood        string
+	MinRPE      int
+	MaxRPE      int
+}
+
 // RecordService 运动记录服务
 type RecordService struct {
 	db *gorm.DB
@@ -20,35 +35,248 @@ func NewRecordService(db *gorm.DB) *RecordService {
 // GetRecords 获取用户的运动记录列表
 func (s *RecordService) GetRecords(userID int64) ([]models.SportRecord, error) {
 	var records []models.SportRecord
-	if err := s.db.Preload("SportType").Where("user_id = ?", userID).Find(&records).Error; err != nil {
+	if err := s.db.Preload("SportType").
+		Preload("Sets", func(db *gorm.DB) *gorm.DB { return db.Order("set_index ASC") }).
+		Preload("Sets.StrengthExercise").
+		Where("user_id = ?", userID).Find(&records).Error; err != nil {
 		return nil, err
 	}
+	for i := range records {
+		records[i].ComputeStrengthSummary()
+	}
 	return records, nil
 }
 
 // CreateRecord 创建运动记录
 func (s *RecordService) CreateRecord(record *models.SportRecord) error {
-	return s.db.Create(record).Error
+	if err := validateRecord(record); err != nil {
+		return err
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return createRecord(tx, record)
+	})
+}
+
+// createRecord 在事务中写入已校验的运动记录及其力量训练分组，未指定可见范围时使用账号的默认设置
+func createRecord(tx *gorm.DB, record *models.SportRecord) error {
+	if record.Visibility == "" {
+		visibility, err := defaultVisibility(tx, record.UserID, "default_record_visibility")
+		if err != nil {
+			return err
+		}
+		record.Visibility = visibility
+	}
+	if err := checkGear(tx, record.UserID, record.GearID); err != nil {
+		return err
+	}
+	if err := checkStrengthExercises(tx, record.UserID, record.Sets); err != nil {
+		return err
+	}
+	if err := tx.Omit("Sets").Create(record).Error; err != nil {
+		return err
+	}
+	if err := createRecordSets(tx, record); err != nil {
+		return err
+	}
+	return refreshChallengeProgress(tx, record.UserID)
 }
 
-// UpdateRecord 更新运动记录
+// UpdateRecord 更新运动记录，Sets 不为 nil 时整体替换该记录的力量训练分组
 func (s *RecordService) UpdateRecord(record *models.SportRecord) error {
-	return s.db.Model(&models.SportRecord{}).Where("id = ?", record.ID).Updates(map[string]interface{}{
-		"sport_type_id": record.SportTypeID,
-		"exercise":      record.Exercise,
-		"duration":      record.Duration,
-		"calories":      record.Calories,
-		"start_time":    record.StartTime,
-		"end_time":      record.EndTime,
-		"image_url":     record.ImageURL,
-		"img_url_list":  record.ImgURLList,
-		"updated_at":    time.Now(),
-	}).Error
-}
-
-// DeleteRecord 删除运动记录
+	if err := validateRecord(record); err != nil {
+		return err
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkGear(tx, record.UserID, record.GearID); err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"sport_type_id":  record.SportTypeID,
+			"exercise":       record.Exercise,
+			"duration":       record.Duration,
+			"calories":       record.Calories,
+			"distance":       record.Distance,
+			"start_time":     record.StartTime,
+			"end_time":       record.EndTime,
+			"image_url":      record.ImageURL,
+			"img_url_list":   record.ImgURLList,
+			"rpe":            record.RPE,
+			"mood":           record.Mood,
+			"notes":          record.Notes,
+			"environment":    record.Environment,
+			"avg_heart_rate": record.AvgHeartRate,
+			"max_heart_rate": record.MaxHeartRate,
+			"gear_id":        record.GearID,
+			"updated_at":     time.Now(),
+		}
+		if record.Visibility != "" {
+			updates["visibility"] = record.Visibility
+		}
+
+		result := tx.Model(&models.SportRecord{}).Where("id = ? AND user_id = ?", record.ID, record.UserID).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if record.Sets != nil {
+			if err := checkStrengthExercises(tx, record.UserID, record.Sets); err != nil {
+				return err
+			}
+			if err := tx.Where("record_id = ?", record.ID).Delete(&models.RecordSet{}).Error; err != nil {
+				return err
+			}
+			if err := createRecordSets(tx, record); err != nil {
+				return err
+			}
+		}
+		return refreshChallengeProgress(tx, record.UserID)
+	})
+}
+
+// ResolveLegacyRecord 根据旧表（exercises/check_ins）的ID查找迁移后的运动记录
+func (s *RecordService) ResolveLegacyRecord(userID int64, sourceTable string, sourceID int64) (*models.SportRecord, error) {
+	var mapping models.LegacyRecordMapping
+	if err := s.db.Where("source_table = ? AND source_id = ?", sourceTable, sourceID).First(&mapping).Error; err != nil {
+		return nil, err
+	}
+
+	var record models.SportRecord
+	if err := s.db.Preload("SportType").
+		Where("id = ? AND user_id = ?", mapping.RecordID, userID).
+		First(&record).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// createRecordSets 写入记录的力量训练分组，并计算汇总数据
+func createRecordSets(tx *gorm.DB, record *models.SportRecord) error {
+	if len(record.Sets) > 0 {
+		for i := range record.Sets {
+			record.Sets[i].ID = 0
+			record.Sets[i].RecordID = record.ID
+			record.Sets[i].SetIndex = i + 1
+		}
+		if err := tx.Omit("StrengthExercise").Create(&record.Sets).Error; err != nil {
+			return err
+		}
+		if err := tx.Preload("StrengthExercise").Where("record_id = ?", record.ID).
+			Order("set_index ASC").Find(&record.Sets).Error; err != nil {
+			return err
+		}
+	}
+	record.ComputeStrengthSummary()
+	return nil
+}
+
+// checkStrengthExercises 确认分组引用的动作存在，且为系统内置或当前用户自定义
+func checkStrengthExercises(tx *gorm.DB, userID int64, sets []models.RecordSet) error {
+	ids := make(map[int64]bool)
+	for _, set := range sets {
+		ids[set.StrengthExerciseID] = true
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	idList := make([]int64, 0, len(ids))
+	for id := range ids {
+		idList = append(idList, id)
+	}
+
+	var count int64
+	if err := tx.Model(&models.StrengthExercise{}).
+		Where("id IN ? AND (user_id IS NULL OR user_id = ?)", idList, userID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count != int64(len(idList)) {
+		return fmt.Errorf("%w: 训练动作不存在", ErrInvalidRecord)
+	}
+	return nil
+}
+
+// validateRecord 校验运动记录中的主观感受、心率及力量训练分组
+func validateRecord(record *models.SportRecord) error {
+	if record.RPE != nil && (*record.RPE < 1 || *record.RPE > 10) {
+		return fmt.Errorf("%w: 主观疲劳度必须在1到10之间", ErrInvalidRecord)
+	}
+
+	switch record.Mood {
+	case "", models.MoodGreat, models.MoodGood, models.MoodNeutral, models.MoodTired, models.MoodBad:
+	default:
+		return fmt.Errorf("%w: 不支持的心情类型 %s", ErrInvalidRecord, record.Mood)
+	}
+
+	switch record.Environment {
+	case "", models.EnvironmentIndoor, models.EnvironmentOutdoor:
+	default:
+		return fmt.Errorf("%w: 运动环境只能是 indoor 或 outdoor", ErrInvalidRecord)
+	}
+
+	if !validVisibility(record.Visibility) {
+		return fmt.Errorf("%w: 不支持的可见范围 %s", ErrInvalidRecord, record.Visibility)
+	}
+
+	if record.Distance < 0 {
+		return fmt.Errorf("%w: 运动距离不能为负数", ErrInvalidRecord)
+	}
+
+	if len([]rune(record.Notes)) > 2000 {
+		return fmt.Errorf("%w: 备注不能超过2000个字符", ErrInvalidRecord)
+	}
+
+	if record.AvgHeartRate != nil && (*record.AvgHeartRate < 30 || *record.AvgHeartRate > 250) {
+		return fmt.Errorf("%w: 平均心率必须在30到250之间", ErrInvalidRecord)
+	}
+	if record.MaxHeartRate != nil && (*record.MaxHeartRate < 30 || *record.MaxHeartRate > 250) {
+		return fmt.Errorf("%w: 最大心率必须在30到250之间", ErrInvalidRecord)
+	}
+	if record.AvgHeartRate != nil && record.MaxHeartRate != nil && *record.AvgHeartRate > *record.MaxHeartRate {
+		return fmt.Errorf("%w: 平均心率不能高于最大心率", ErrInvalidRecord)
+	}
+
+	for i, set := range record.Sets {
+		if set.StrengthExerciseID <= 0 {
+			return fmt.Errorf("%w: 第%d组缺少训练动作", ErrInvalidRecord, i+1)
+		}
+		if set.Reps < 1 || set.Reps > 1000 {
+			return fmt.Errorf("%w: 第%d组次数必须在1到1000之间", ErrInvalidRecord, i+1)
+		}
+		if set.Weight < 0 || set.Weight > 1000 {
+			return fmt.Errorf("%w: 第%d组重量必须在0到1000千克之间", ErrInvalidRecord, i+1)
+		}
+		if set.RestSeconds < 0 {
+			return fmt.Errorf("%w: 第%d组休息时间不能为负数", ErrInvalidRecord, i+1)
+		}
+		if set.RPE != nil && (*set.RPE < 1 || *set.RPE > 10) {
+			return fmt.Errorf("%w: 第%d组主观疲劳度必须在1到10之间", ErrInvalidRecord, i+1)
+		}
+	}
+
+	return nil
+}
+
+// DeleteRecord 删除运动记录及其力量训练组，同时解除与活动的关联并更新挑战进度
 func (s *RecordService) DeleteRecord(id int64, userID int64) error {
-	return s.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.SportRecord{}).Error
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&models.SportRecord{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := tx.Where("record_id = ?", id).Delete(&models.RecordSet{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.EventRSVP{}).Where("sport_record_id = ?", id).
+			Update("sport_record_id", nil).Error; err != nil {
+			return err
+		}
+		return refreshChallengeProgress(tx, userID)
+	})
 }
 
 // GetSportTypes 获取所有运动类型
@@ -73,8 +301,28 @@ func (s *RecordService) DeleteSportType(id int64) error {
 	return s.db.Delete(&models.SportType{}, id).Error
 }
 
+// applyStatsFilter 为统计查询添加运动类型与主观感受筛选条件
+func applyStatsFilter(query *gorm.DB, filter StatsFilter) *gorm.DB {
+	if filter.SportTypeID > 0 {
+		query = query.Where("sport_type_id = ?", filter.SportTypeID)
+	}
+	if filter.Environment != "" {
+		query = query.Where("environment = ?", filter.Environment)
+	}
+	if filter.Mood != "" {
+		query = query.Where("mood = ?", filter.Mood)
+	}
+	if filter.MinRPE > 0 {
+		query = query.Where("rpe >= ?", filter.MinRPE)
+	}
+	if filter.MaxRPE > 0 {
+		query = query.Where("rpe <= ?", filter.MaxRPE)
+	}
+	return query
+}
+
 // GetStats 获取用户的运动统计信息
-func (s *RecordService) GetStats(userID int64, timeRange string, sportTypeID int64) (*models.Stats, error) {
+func (s *RecordService) GetStats(userID int64, filter StatsFilter) (*models.Stats, error) {
 	var stats models.Stats
 	
 	// 构建基础查询
@@ -82,7 +330,7 @@ func (s *RecordService) GetStats(userID int64, timeRange string, sportTypeID int
 	
 	// 添加时间范围筛选
 	now := time.Now()
-	switch timeRange {
+	switch filter.TimeRange {
 	case "week":
 		startTime := now.AddDate(0, 0, -7)
 		baseQuery = baseQuery.Where("start_time >= ?", startTime)
@@ -94,10 +342,8 @@ func (s *RecordService) GetStats(userID int64, timeRange string, sportTypeID int
 		baseQuery = baseQuery.Where("start_time >= ?", startTime)
 	}
 	
-	// 添加运动类型筛选
-	if sportTypeID > 0 {
-		baseQuery = baseQuery.Where("sport_type_id = ?", sportTypeID)
-	}
+	// 添加运动类型及主观感受筛选
+	baseQuery = applyStatsFilter(baseQuery, filter)
 	
 	// 获取总运动时长
 	if err := baseQuery.Select("COALESCE(SUM(duration), 0) as total_duration").
@@ -122,6 +368,22 @@ func (s *RecordService) GetStats(userID int64, timeRange string, sportTypeID int
 		return nil, err
 	}
 
+	// 获取平均主观疲劳度及心率（未填写的记录不参与计算）
+	var feel struct {
+		AverageRPE          float64
+		AverageHeartRate    float64
+		AverageMaxHeartRate float64
+	}
+	if err := baseQuery.Select("COALESCE(AVG(rpe), 0) as average_rpe, " +
+		"COALESCE(AVG(avg_heart_rate), 0) as average_heart_rate, " +
+		"COALESCE(AVG(max_heart_rate), 0) as average_max_heart_rate").
+		Scan(&feel).Error; err != nil {
+		return nil, err
+	}
+	stats.AverageRPE = feel.AverageRPE
+	stats.AverageHeartRate = feel.AverageHeartRate
+	stats.AverageMaxHeartRate = feel.AverageMaxHeartRate
+
 	// 初始化每日统计数组
 	stats.DailyDuration = make([]int64, 7)
 	stats.DailyCount = make([]int64, 7)
@@ -136,10 +398,8 @@ func (s *RecordService) GetStats(userID int64, timeRange string, sportTypeID int
 		dailyQuery := s.db.Model(&models.SportRecord{}).
 			Where("user_id = ? AND start_time >= ? AND start_time < ?", userID, startDate, endDate)
 		
-		// 添加运动类型筛选
-		if sportTypeID > 0 {
-			dailyQuery = dailyQuery.Where("sport_type_id = ?", sportTypeID)
-		}
+		// 添加运动类型及主观感受筛选
+		dailyQuery = applyStatsFilter(dailyQuery, filter)
 
 		// 获取每日运动时长
 		var dailyDuration int64
@@ -158,4 +418,4 @@ func (s *RecordService) GetStats(userID int64, timeRange string, sportTypeID int
 	}
 	
 	return &stats, nil
-} 
\ No newline at end of file
+}
\ No newline at end of file
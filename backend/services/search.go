@@ -0,0 +1,331 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"sports-app/backend/models"
+	"sports-app/backend/utils"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// 搜索限制
+const (
+	maxSearchQueryLength = 50
+	maxSearchTerms       = 5
+	ngramTokenSize       = 2 // MySQL ngram_token_size 的默认值，短于该长度的关键词无法通过全文索引匹配
+	searchSnippetRadius  = 30
+)
+
+// ErrInvalidSearch 搜索参数校验失败
+var ErrInvalidSearch = errors.New("无效的搜索")
+
+// searchTypes 可搜索的类型，按返回顺序排列
+var searchTypes = []string{
+	models.SearchTypeRecords,
+	models.SearchTypeCheckIns,
+	models.SearchTypeUsers,
+	models.SearchTypeSportTypes,
+}
+
+// likeEscaper 转义 LIKE 中的通配符
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchInput 搜索参数
+type SearchInput struct {
+	Query string `form:"q"`
+	Type  string `form:"type"` // records/check_ins/users/sport_types，为空时搜索全部类型
+}
+
+// SearchService 全站搜索服务
+type SearchService struct {
+	db *gorm.DB
+}
+
+// NewSearchService 创建搜索服务实例
+func NewSearchService(db *gorm.DB) *SearchService {
+	return &SearchService{db: db}
+}
+
+// Search 搜索本人的运动记录、可见的打卡、用户和运动类型，每种类型分别分页
+func (s *SearchService) Search(viewerID int64, input SearchInput, page, pageSize int) (*models.SearchResult, error) {
+	query := strings.TrimSpace(input.Query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: 搜索关键词不能为空", ErrInvalidSearch)
+	}
+	if len([]rune(query)) > maxSearchQueryLength {
+		return nil, fmt.Errorf("%w: 搜索关键词不能超过%d个字符", ErrInvalidSearch, maxSearchQueryLength)
+	}
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: 搜索关键词不能为空", ErrInvalidSearch)
+	}
+
+	types := searchTypes
+	if input.Type != "" {
+		valid := false
+		for _, t := range searchTypes {
+			valid = valid || t == input.Type
+		}
+		if !valid {
+			return nil, fmt.Errorf("%w: 搜索类型只能是 records/check_ins/users/sport_types", ErrInvalidSearch)
+		}
+		types = []string{input.Type}
+	}
+
+	page, pageSize = normalizePage(page, pageSize)
+	result := &models.SearchResult{Query: query, Page: page, PageSize: pageSize, Groups: make([]models.SearchGroup, 0, len(types))}
+	for _, t := range types {
+		var group *models.SearchGroup
+		var err error
+		switch t {
+		case models.SearchTypeRecords:
+			group, err = s.searchRecords(viewerID, terms, page, pageSize)
+		case models.SearchTypeCheckIns:
+			group, err = s.searchCheckIns(viewerID, terms, page, pageSize)
+		case models.SearchTypeUsers:
+			group, err = s.searchUsers(viewerID, terms, page, pageSize)
+		case models.SearchTypeSportTypes:
+			group, err = s.searchSportTypes(terms, page, pageSize)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Groups = append(result.Groups, *group)
+	}
+	return result, nil
+}
+
+// searchRecords 搜索本人运动记录的项目名称和备注
+func (s *SearchService) searchRecords(viewerID int64, terms []string, page, pageSize int) (*models.SearchGroup, error) {
+	cond, args := s.matchCondition([]string{"sport_records.exercise", "sport_records.notes"}, terms)
+	query := s.db.Model(&models.SportRecord{}).Where("sport_records.user_id = ?", viewerID).Where(cond, args...)
+
+	group := &models.SearchGroup{Type: models.SearchTypeRecords, Items: make([]models.SearchHit, 0)}
+	if err := query.Count(&group.Total).Error; err != nil {
+		return nil, err
+	}
+	var records []models.SportRecord
+	if err := query.Preload("SportType").
+		Order("start_time DESC, id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		group.Items = append(group.Items, models.SearchHit{
+			ID:         uint64(record.ID),
+			Highlights: highlights(terms, map[string]string{"exercise": record.Exercise, "notes": record.Notes}),
+			Data:       record,
+		})
+	}
+	return group, nil
+}
+
+// searchCheckIns 搜索当前用户可见的打卡描述，与当前用户存在屏蔽关系的用户的打卡不返回
+func (s *SearchService) searchCheckIns(viewerID int64, terms []string, page, pageSize int) (*models.SearchGroup, error) {
+	cond, args := s.matchCondition([]string{"check_ins.description"}, terms)
+	query := visibleCheckIns(s.db.Model(&models.CheckIn{}).Where(cond, args...), viewerID)
+
+	group := &models.SearchGroup{Type: models.SearchTypeCheckIns, Items: make([]models.SearchHit, 0)}
+	if err := query.Count(&group.Total).Error; err != nil {
+		return nil, err
+	}
+	var checkIns []models.CheckIn
+	if err := query.Preload("User").Preload("SportType").
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&checkIns).Error; err != nil {
+		return nil, err
+	}
+	for _, checkIn := range checkIns {
+		group.Items = append(group.Items, models.SearchHit{
+			ID:         checkIn.ID,
+			Highlights: highlights(terms, map[string]string{"description": checkIn.Description}),
+			Data:       checkIn,
+		})
+	}
+	return group, nil
+}
+
+// searchUsers 搜索用户名，资料仅自己可见的用户、仅关注者可见且未关注的用户以及存在屏蔽关系的用户不返回
+// 用户名越短越接近关键词，排在前面
+func (s *SearchService) searchUsers(viewerID int64, terms []string, page, pageSize int) (*models.SearchGroup, error) {
+	cond, args := s.matchCondition([]string{"users.username"}, terms)
+	followees := s.db.Model(&models.Follow{}).Select("followee_id").Where("follower_id = ?", viewerID)
+	query := s.db.Model(&models.PublicUser{}).
+		Where("users.deleted_at IS NULL").
+		Where(cond, args...).
+		Where("users.id = ? OR users.profile_visibility = ? OR (users.profile_visibility = ? AND users.id IN (?))",
+			viewerID, models.VisibilityPublic, models.VisibilityFollowers, followees)
+	query = excludeBlocked(query, "users.id", viewerID)
+
+	group := &models.SearchGroup{Type: models.SearchTypeUsers, Items: make([]models.SearchHit, 0)}
+	if err := query.Count(&group.Total).Error; err != nil {
+		return nil, err
+	}
+	var users []models.PublicUser
+	if err := query.Order("LENGTH(users.username) ASC, users.id ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		group.Items = append(group.Items, models.SearchHit{
+			ID:         uint64(user.ID),
+			Highlights: highlights(terms, map[string]string{"username": user.Username}),
+			Data:       user,
+		})
+	}
+	return group, nil
+}
+
+// searchSportTypes 搜索运动类型名称
+func (s *SearchService) searchSportTypes(terms []string, page, pageSize int) (*models.SearchGroup, error) {
+	cond, args := s.matchCondition([]string{"sport_types.name"}, terms)
+	query := s.db.Model(&models.SportType{}).Where(cond, args...)
+
+	group := &models.SearchGroup{Type: models.SearchTypeSportTypes, Items: make([]models.SearchHit, 0)}
+	if err := query.Count(&group.Total).Error; err != nil {
+		return nil, err
+	}
+	var sportTypes []models.SportType
+	if err := query.Order("id ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&sportTypes).Error; err != nil {
+		return nil, err
+	}
+	for _, sportType := range sportTypes {
+		group.Items = append(group.Items, models.SearchHit{
+			ID:         uint64(sportType.ID),
+			Highlights: highlights(terms, map[string]string{"name": sportType.Name}),
+			Data:       sportType,
+		})
+	}
+	return group, nil
+}
+
+// searchTerms 按空白拆分关键词并去重，去掉会破坏全文检索短语的双引号
+func searchTerms(query string) []string {
+	terms := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, term := range strings.Fields(strings.ReplaceAll(query, `"`, " ")) {
+		if !seen[term] && len(terms) < maxSearchTerms {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// matchCondition 生成所有关键词都要命中的匹配条件
+// MySQL 使用 ngram 全文索引，columns 需要与全文索引的字段完全一致；其他数据库以及短于分词长度的关键词使用 LIKE
+func (s *SearchService) matchCondition(columns []string, terms []string) (string, []interface{}) {
+	fulltext := s.db.Dialector.Name() == "mysql"
+	for _, term := range terms {
+		if len([]rune(term)) < ngramTokenSize {
+			fulltext = false
+		}
+	}
+
+	if fulltext {
+		phrases := make([]string, len(terms))
+		for i, term := range terms {
+			phrases[i] = `+"` + term + `"`
+		}
+		sql := fmt.Sprintf("MATCH(%s) AGAINST (? IN BOOLEAN MODE)", strings.Join(columns, ", "))
+		return sql, []interface{}{strings.Join(phrases, " ")}
+	}
+
+	conds := make([]string, 0, len(terms))
+	args := make([]interface{}, 0, len(terms)*len(columns))
+	for _, term := range terms {
+		likes := make([]string, len(columns))
+		for i, column := range columns {
+			likes[i] = column + " LIKE ?"
+			args = append(args, "%"+likeEscaper.Replace(term)+"%")
+		}
+		conds = append(conds, "("+strings.Join(likes, " OR ")+")")
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// highlights 生成各字段的命中片段，没有命中的字段不返回
+func highlights(terms []string, fields map[string]string) map[string]string {
+	result := make(map[string]string)
+	for field, text := range fields {
+		if snippet := highlight(text, terms); snippet != "" {
+			result[field] = snippet
+		}
+	}
+	return result
+}
+
+// highlight 截取第一个命中位置附近的内容，匹配部分用 <em></em> 标记，其余内容做 HTML 转义
+// 匹配时忽略大小写、全半角和简繁体差异
+func highlight(text string, terms []string) string {
+	runes := []rune(text)
+	normalized := make([]rune, len(runes))
+	for i, r := range runes {
+		normalized[i] = utils.NormalizeRune(r)
+	}
+
+	marked := make([]bool, len(runes))
+	first := -1
+	for _, term := range terms {
+		needle := []rune(term)
+		for i, r := range needle {
+			needle[i] = utils.NormalizeRune(r)
+		}
+		for i := 0; i+len(needle) <= len(normalized); i++ {
+			if string(normalized[i:i+len(needle)]) != string(needle) {
+				continue
+			}
+			for j := i; j < i+len(needle); j++ {
+				marked[j] = true
+			}
+			if first < 0 || i < first {
+				first = i
+			}
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+
+	start := first - searchSnippetRadius
+	if start < 0 {
+		start = 0
+	}
+	end := first + 2*searchSnippetRadius
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		j := i
+		for j < end && marked[j] == marked[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			segment = "<em>" + segment + "</em>"
+		}
+		b.WriteString(segment)
+		i = j
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
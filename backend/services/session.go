@@ -0,0 +1,370 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"sports-app/backend/config"
+	"sports-app/backend/models"
+	"sports-app/backend/token"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+// 会话状态缓存
+const (
+	sessionCacheTTL        = 30 * time.Second // 其他实例吊销的会话最多延迟该时长生效
+	sessionCachePurgeEvery = time.Minute
+	sessionPurgeBatch      = 500
+)
+
+var (
+	// ErrInvalidRefreshToken 刷新令牌不存在或已过期
+	ErrInvalidRefreshToken = errors.New("无效的刷新令牌")
+	// ErrRefreshTokenReused 已轮换的刷新令牌被再次使用，会话已被吊销
+	ErrRefreshTokenReused = errors.New("刷新令牌已被使用，请重新登录")
+	// ErrSessionRevoked 会话已失效
+	ErrSessionRevoked = errors.New("登录已失效，请重新登录")
+)
+
+// sessionState 缓存的会话状态
+type sessionState struct {
+	userID    int64
+	active    bool
+	checkedAt time.Time
+}
+
+// sessionCache 会话状态的进程内缓存，认证中间件命中缓存时不查询数据库
+// 本实例吊销会话时立即更新缓存，其他实例在缓存过期后重新读取
+type sessionCache struct {
+	mu         sync.Mutex
+	states     map[uint64]sessionState
+	lastPurged time.Time
+}
+
+var (
+	defaultSessionCache     *sessionCache
+	defaultSessionCacheOnce sync.Once
+)
+
+// getSessionCache 获取全局会话状态缓存
+func getSessionCache() *sessionCache {
+	defaultSessionCacheOnce.Do(func() {
+		defaultSessionCache = &sessionCache{states: make(map[uint64]sessionState), lastPurged: time.Now()}
+	})
+	return defaultSessionCache
+}
+
+// get 读取未过期的会话状态
+func (c *sessionCache) get(sessionID uint64) (sessionState, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	state, ok := c.states[sessionID]
+	if !ok || time.Since(state.checkedAt) > sessionCacheTTL {
+		return sessionState{}, false
+	}
+	return state, true
+}
+
+// set 写入会话状态，并定期清理过期的缓存
+func (c *sessionCache) set(sessionID uint64, userID int64, active bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if now.Sub(c.lastPurged) > sessionCachePurgeEvery {
+		for id, state := range c.states {
+			if now.Sub(state.checkedAt) > sessionCacheTTL {
+				delete(c.states, id)
+			}
+		}
+		c.lastPurged = now
+	}
+	c.states[sessionID] = sessionState{userID: userID, active: active, checkedAt: now}
+}
+
+// revokeUser 将用户已缓存的会话标记为失效
+func (c *sessionCache) revokeUser(userID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for id, state := range c.states {
+		if state.userID == userID {
+			c.states[id] = sessionState{userID: userID, active: false, checkedAt: now}
+		}
+	}
+}
+
+// SessionService 登录会话服务，负责签发、轮换和吊销令牌
+type SessionService struct {
+	db         *gorm.DB
+	cache      *sessionCache
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// NewSessionService 创建登录会话服务实例
+func NewSessionService(db *gorm.DB) *SessionService {
+	cfg := config.GetConfig().JWT
+	return &SessionService{
+		db:         db,
+		cache:      getSessionCache(),
+		accessTTL:  time.Duration(cfg.ExpiresIn) * time.Second,
+		refreshTTL: time.Duration(cfg.RefreshExpiresIn) * time.Second,
+	}
+}
+
+// newRefreshToken 生成随机刷新令牌
+func newRefreshToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashRefreshToken 计算刷新令牌的摘要，数据库中不保存令牌原文
+func hashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateSession 登录成功后创建会话并签发令牌
+func (s *SessionService) CreateSession(userID int64, userAgent, ip string) (*models.TokenPair, error) {
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	now := time.Now()
+	session := models.AuthSession{
+		UserID:     userID,
+		UserAgent:  userAgent,
+		IP:         ip,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(s.refreshTTL),
+	}
+
+	var refreshToken string
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+		var err error
+		refreshToken, err = s.issueRefreshToken(tx, session.ID, session.ExpiresAt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.cache.set(session.ID, userID, true)
+	return s.tokenPair(userID, session.ID, refreshToken, session.ExpiresAt)
+}
+
+// Refresh 使用刷新令牌换取新的访问令牌和刷新令牌，旧刷新令牌随即失效
+// 已使用过的刷新令牌再次出现说明令牌可能已泄露，吊销整个会话
+func (s *SessionService) Refresh(refreshToken string) (*models.TokenPair, error) {
+	var session models.AuthSession
+	var newToken string
+	reused := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var current models.RefreshToken
+		if err := tx.Where("token_hash = ?", hashRefreshToken(refreshToken)).First(&current).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidRefreshToken
+			}
+			return err
+		}
+		if err := tx.First(&session, current.SessionID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidRefreshToken
+			}
+			return err
+		}
+		if session.RevokedAt != nil {
+			return ErrSessionRevoked
+		}
+
+		now := time.Now()
+		if current.UsedAt != nil {
+			reused = true
+			_, err := revokeSessions(tx.Where("id = ?", session.ID), models.SessionRevokeReuse)
+			return err
+		}
+		if now.After(current.ExpiresAt) {
+			return ErrInvalidRefreshToken
+		}
+
+		// 并发刷新时只有一个请求能标记成功，其余按重复使用处理
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", current.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			reused = true
+			_, err := revokeSessions(tx.Where("id = ?", session.ID), models.SessionRevokeReuse)
+			return err
+		}
+
+		session.LastUsedAt = now
+		session.ExpiresAt = now.Add(s.refreshTTL)
+		if err := tx.Model(&session).Updates(map[string]interface{}{
+			"last_used_at": session.LastUsedAt,
+			"expires_at":   session.ExpiresAt,
+		}).Error; err != nil {
+			return err
+		}
+		var err error
+		newToken, err = s.issueRefreshToken(tx, session.ID, session.ExpiresAt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		s.cache.set(session.ID, session.UserID, false)
+		GetHub().DisconnectSession(session.UserID, session.ID)
+		log.Printf("用户 %d 的会话 %d 检测到刷新令牌重复使用，已吊销", session.UserID, session.ID)
+		return nil, ErrRefreshTokenReused
+	}
+	return s.tokenPair(session.UserID, session.ID, newToken, session.ExpiresAt)
+}
+
+// Revoke 吊销当前会话，并断开该会话的推送连接
+func (s *SessionService) Revoke(userID int64, sessionID uint64) error {
+	if _, err := revokeSessions(s.db.Where("id = ? AND user_id = ?", sessionID, userID), models.SessionRevokeLogout); err != nil {
+		return err
+	}
+	s.cache.set(sessionID, userID, false)
+	GetHub().DisconnectSession(userID, sessionID)
+	return nil
+}
+
+// RevokeAll 吊销用户的所有会话并断开其推送连接，返回吊销的会话数
+func (s *SessionService) RevokeAll(userID int64, reason string) (int64, error) {
+	revoked, err := revokeSessions(s.db.Where("user_id = ?", userID), reason)
+	if err != nil {
+		return 0, err
+	}
+	s.cache.revokeUser(userID)
+	GetHub().DisconnectUser(userID)
+	return revoked, nil
+}
+
+// IsActive 判断访问令牌所属的会话是否仍然有效，优先读取缓存
+func (s *SessionService) IsActive(userID int64, sessionID uint64) (bool, error) {
+	if state, ok := s.cache.get(sessionID); ok {
+		return state.active && state.userID == userID, nil
+	}
+
+	var session models.AuthSession
+	if err := s.db.Select("id", "user_id", "revoked_at").First(&session, sessionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	active := session.RevokedAt == nil
+	s.cache.set(session.ID, session.UserID, active)
+	return active && session.UserID == userID, nil
+}
+
+// PurgeExpired 清理刷新令牌已过期的会话及其令牌，返回删除的会话数
+func (s *SessionService) PurgeExpired(now time.Time) (int64, error) {
+	var purged int64
+	for {
+		var ids []uint64
+		if err := s.db.Model(&models.AuthSession{}).
+			Where("expires_at < ?", now).
+			Limit(sessionPurgeBatch).
+			Pluck("id", &ids).Error; err != nil {
+			return purged, err
+		}
+		if len(ids) == 0 {
+			return purged, nil
+		}
+
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("session_id IN ?", ids).Delete(&models.RefreshToken{}).Error; err != nil {
+				return err
+			}
+			return tx.Where("id IN ?", ids).Delete(&models.AuthSession{}).Error
+		})
+		if err != nil {
+			return purged, err
+		}
+		purged += int64(len(ids))
+	}
+}
+
+// StartRetention 定期清理过期会话，在后台协程中运行
+func (s *SessionService) StartRetention(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if purged, err := s.PurgeExpired(time.Now()); err != nil {
+			log.Printf("清理过期会话失败: %v", err)
+		} else if purged > 0 {
+			log.Printf("已清理过期会话 %d 个", purged)
+		}
+		<-ticker.C
+	}
+}
+
+// issueRefreshToken 在调用方的事务中为会话签发新的刷新令牌
+func (s *SessionService) issueRefreshToken(tx *gorm.DB, sessionID uint64, expiresAt time.Time) (string, error) {
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return "", err
+	}
+	record := models.RefreshToken{
+		SessionID: sessionID,
+		TokenHash: hashRefreshToken(refreshToken),
+		ExpiresAt: expiresAt,
+	}
+	if err := tx.Create(&record).Error; err != nil {
+		return "", err
+	}
+	return refreshToken, nil
+}
+
+// tokenPair 签发访问令牌并与刷新令牌一起返回
+func (s *SessionService) tokenPair(userID int64, sessionID uint64, refreshToken string, refreshExpiresAt time.Time) (*models.TokenPair, error) {
+	manager, err := token.Default()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	expiresAt := now.Add(s.accessTTL)
+	accessToken, err := manager.Sign(&token.Claims{
+		UserID:    userID,
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.TokenPair{
+		Token:            accessToken,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+// revokeSessions 将查询条件匹配的未失效会话标记为失效，返回吊销的会话数
+func revokeSessions(query *gorm.DB, reason string) (int64, error) {
+	result := query.Model(&models.AuthSession{}).
+		Where("revoked_at IS NULL").
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoke_reason": reason})
+	return result.RowsAffected, result.Error
+}
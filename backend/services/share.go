@@ -0,0 +1,283 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sports-app/backend/config"
+	"sports-app/backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 分享链接限制
+const (
+	shareTokenBytes     = 32
+	maxShareExpireHours = 365 * 24
+	maxSharesPerRecord  = 20
+)
+
+// ErrInvalidShare 分享参数校验失败
+var ErrInvalidShare = errors.New("无效的分享")
+
+// CreateShareInput 创建分享链接的参数
+type CreateShareInput struct {
+	ExpiresInHours int `json:"expires_in_hours"` // 有效时长（小时），0 表示长期有效
+}
+
+// ShareService 运动记录分享服务
+type ShareService struct {
+	db      *gorm.DB
+	baseURL string
+}
+
+// NewShareService 创建分享服务实例
+func NewShareService(db *gorm.DB) *ShareService {
+	return &ShareService{db: db, baseURL: config.LoadShareConfig().BaseURL}
+}
+
+// newShareToken 生成分享 token，256 位随机数，无法猜测
+func newShareToken() (string, error) {
+	buf := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// shareURL 拼接分享页地址
+func (s *ShareService) shareURL(token string) string {
+	return s.baseURL + "/share/" + token
+}
+
+// findShareableRecord 查找用户自己的、可以分享的运动记录
+// 分享链接无需登录即可访问，只有公开的记录可以分享，避免链接扩大仅关注者可见记录的可见范围
+func (s *ShareService) findShareableRecord(userID, recordID int64) (*models.SportRecord, error) {
+	var record models.SportRecord
+	if err := s.db.Select("id", "user_id", "visibility").
+		Where("id = ? AND user_id = ?", recordID, userID).
+		First(&record).Error; err != nil {
+		return nil, err
+	}
+	if record.Visibility != models.VisibilityPublic {
+		return nil, fmt.Errorf("%w: 只有公开的记录可以分享，请先修改可见范围", ErrInvalidShare)
+	}
+	return &record, nil
+}
+
+// CreateShare 为运动记录创建分享链接
+func (s *ShareService) CreateShare(userID, recordID int64, input CreateShareInput) (*models.RecordShare, error) {
+	if input.ExpiresInHours < 0 || input.ExpiresInHours > maxShareExpireHours {
+		return nil, fmt.Errorf("%w: 有效时长必须在0到%d小时之间", ErrInvalidShare, maxShareExpireHours)
+	}
+	if _, err := s.findShareableRecord(userID, recordID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var active int64
+	if err := s.activeShares(recordID, now).Count(&active).Error; err != nil {
+		return nil, err
+	}
+	if active >= maxSharesPerRecord {
+		return nil, fmt.Errorf("%w: 每条记录最多同时存在%d个分享链接", ErrInvalidShare, maxSharesPerRecord)
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, err
+	}
+	share := &models.RecordShare{RecordID: recordID, UserID: userID, Token: token}
+	if input.ExpiresInHours > 0 {
+		expiresAt := now.Add(time.Duration(input.ExpiresInHours) * time.Hour)
+		share.ExpiresAt = &expiresAt
+	}
+	if err := s.db.Create(share).Error; err != nil {
+		return nil, err
+	}
+	share.URL = s.shareURL(share.Token)
+	return share, nil
+}
+
+// activeShares 记录未撤销且未过期的分享链接
+func (s *ShareService) activeShares(recordID int64, now time.Time) *gorm.DB {
+	return s.db.Model(&models.RecordShare{}).
+		Where("record_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", recordID, now)
+}
+
+// GetShares 获取记录当前有效的分享链接
+func (s *ShareService) GetShares(userID, recordID int64) ([]models.RecordShare, error) {
+	var record models.SportRecord
+	if err := s.db.Select("id").Where("id = ? AND user_id = ?", recordID, userID).First(&record).Error; err != nil {
+		return nil, err
+	}
+
+	shares := make([]models.RecordShare, 0)
+	if err := s.activeShares(recordID, time.Now()).Order("id DESC").Find(&shares).Error; err != nil {
+		return nil, err
+	}
+	for i := range shares {
+		shares[i].URL = s.shareURL(shares[i].Token)
+	}
+	return shares, nil
+}
+
+// RevokeShare 撤销分享链接，重复撤销不报错
+func (s *ShareService) RevokeShare(userID, recordID int64, shareID uint64) error {
+	var share models.RecordShare
+	if err := s.db.Where("id = ? AND record_id = ? AND user_id = ?", shareID, recordID, userID).
+		First(&share).Error; err != nil {
+		return err
+	}
+	if share.RevokedAt != nil {
+		return nil
+	}
+	return s.db.Model(&share).Update("revoked_at", time.Now()).Error
+}
+
+// findSharedRecord 查找分享 token 对应的有效链接和记录
+// 链接已撤销、已过期，或记录被删除、不再公开时视为不存在
+func (s *ShareService) findSharedRecord(token string) (*models.RecordShare, *models.SportRecord, error) {
+	if token == "" || len(token) > 64 {
+		return nil, nil, gorm.ErrRecordNotFound
+	}
+
+	var share models.RecordShare
+	if err := s.db.Where("token = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", token, time.Now()).
+		First(&share).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var record models.SportRecord
+	if err := s.db.Preload("SportType").
+		Where("id = ? AND user_id = ? AND visibility = ?", share.RecordID, share.UserID, models.VisibilityPublic).
+		First(&record).Error; err != nil {
+		return nil, nil, err
+	}
+	return &share, &record, nil
+}
+
+// GetSharedRecord 通过分享 token 获取记录摘要，无需登录
+func (s *ShareService) GetSharedRecord(token string) (*models.SharedRecord, error) {
+	share, record, err := s.findSharedRecord(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var user models.PublicUser
+	if err := s.db.First(&user, record.UserID).Error; err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Model(share).UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error; err != nil {
+		return nil, err
+	}
+
+	return &models.SharedRecord{
+		User:        user,
+		SportType:   record.SportType.Name,
+		Exercise:    record.Exercise,
+		StartTime:   record.StartTime,
+		EndTime:     record.EndTime,
+		Duration:    record.Duration,
+		Distance:    record.Distance,
+		Calories:    record.Calories,
+		Images:      recordImages(record),
+		Environment: record.Environment,
+		ExpiresAt:   share.ExpiresAt,
+		URL:         s.shareURL(share.Token),
+		CardURL:     s.baseURL + "/api/share/" + share.Token + "/card",
+	}, nil
+}
+
+// RecordCard 绘制本人运动记录的分享卡片，返回 PNG 和用于缓存校验的 ETag
+func (s *ShareService) RecordCard(userID, recordID int64, theme string) ([]byte, string, error) {
+	var record models.SportRecord
+	if err := s.db.Preload("SportType").Where("id = ? AND user_id = ?", recordID, userID).
+		First(&record).Error; err != nil {
+		return nil, "", err
+	}
+	return s.renderCard(&record, theme)
+}
+
+// SharedRecordCard 通过分享 token 绘制分享卡片，无需登录，不计入访问次数
+func (s *ShareService) SharedRecordCard(token, theme string) ([]byte, string, error) {
+	_, record, err := s.findSharedRecord(token)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.renderCard(record, theme)
+}
+
+// renderCard 绘制分享卡片，按卡片上展示的全部内容和主题缓存
+// 记录、用户名或运动类型修改后缓存键和 ETag 随之变化，自动重新绘制
+func (s *ShareService) renderCard(record *models.SportRecord, theme string) ([]byte, string, error) {
+	if theme == "" {
+		theme = DefaultShareCardTheme
+	}
+	if !validShareCardTheme(theme) {
+		return nil, "", fmt.Errorf("%w: 卡片主题只能是 light/dark/vivid", ErrInvalidShare)
+	}
+
+	var user models.PublicUser
+	if err := s.db.First(&user, record.UserID).Error; err != nil {
+		return nil, "", err
+	}
+
+	headline := record.Exercise
+	if headline == "" {
+		headline = record.SportType.Name
+	}
+	data := shareCardData{
+		SportName: record.SportType.Name,
+		Icon:      record.SportType.Icon,
+		Headline:  headline,
+		Username:  user.Username,
+		StartTime: record.StartTime,
+		Duration:  record.Duration,
+		Distance:  record.Distance,
+		Calories:  record.Calories,
+	}
+	content, err := json.Marshal(data)
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(content)
+	key := fmt.Sprintf("%d-%s-%s", record.ID, theme, hex.EncodeToString(sum[:12]))
+
+	renderer, err := GetShareCardRenderer()
+	if err != nil {
+		return nil, "", err
+	}
+	card, err := renderer.Render(key, data, theme)
+	if err != nil {
+		return nil, "", err
+	}
+	return card, `"` + key + `"`, nil
+}
+
+// recordImages 合并记录的封面图和图片列表，去除重复项
+func recordImages(record *models.SportRecord) []string {
+	images := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(url string) {
+		if url != "" && !seen[url] {
+			seen[url] = true
+			images = append(images, url)
+		}
+	}
+
+	add(record.ImageURL)
+	var list []string
+	if record.ImgURLList != "" && json.Unmarshal([]byte(record.ImgURLList), &list) == nil {
+		for _, url := range list {
+			add(url)
+		}
+	}
+	return images
+}
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sports-app/backend/config"
+	"strconv"
+	"sync"
+	"time"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// 分享卡片尺寸与缓存容量
+const (
+	shareCardWidth     = 750
+	shareCardHeight    = 600
+	shareCardMargin    = 60
+	shareCardIconSize  = 120
+	shareCardCacheSize = 256
+)
+
+// DefaultShareCardTheme 未指定主题时使用的卡片主题
+const DefaultShareCardTheme = "light"
+
+// shareCardTheme 分享卡片配色
+type shareCardTheme struct {
+	top, bottom color.RGBA // 背景渐变的起止颜色
+	panel       color.RGBA // 数据面板底色
+	accent      color.RGBA // 图标底色和数据数值
+	text        color.RGBA
+	muted       color.RGBA
+}
+
+// shareCardThemes 可选的卡片主题
+var shareCardThemes = map[string]shareCardTheme{
+	"light": {
+		top:    color.RGBA{0xFF, 0xFF, 0xFF, 0xFF},
+		bottom: color.RGBA{0xF2, 0xF3, 0xF5, 0xFF},
+		panel:  color.RGBA{0xFF, 0xF1, 0xEA, 0xFF},
+		accent: color.RGBA{0xFF, 0x6B, 0x35, 0xFF},
+		text:   color.RGBA{0x22, 0x22, 0x22, 0xFF},
+		muted:  color.RGBA{0x88, 0x88, 0x88, 0xFF},
+	},
+	"dark": {
+		top:    color.RGBA{0x1C, 0x1C, 0x1E, 0xFF},
+		bottom: color.RGBA{0x0B, 0x0B, 0x0C, 0xFF},
+		panel:  color.RGBA{0x2C, 0x2C, 0x2E, 0xFF},
+		accent: color.RGBA{0x30, 0xD1, 0x58, 0xFF},
+		text:   color.RGBA{0xF5, 0xF5, 0xF7, 0xFF},
+		muted:  color.RGBA{0x98, 0x98, 0x9D, 0xFF},
+	},
+	"vivid": {
+		top:    color.RGBA{0xFF, 0x5F, 0x6D, 0xFF},
+		bottom: color.RGBA{0xFF, 0xC3, 0x71, 0xFF},
+		panel:  color.RGBA{0x40, 0x40, 0x40, 0x40}, // 25% 不透明度的白色（预乘 alpha）
+		accent: color.RGBA{0xFF, 0xFF, 0xFF, 0xFF},
+		text:   color.RGBA{0xFF, 0xFF, 0xFF, 0xFF},
+		muted:  color.RGBA{0xFF, 0xF4, 0xE8, 0xFF},
+	},
+}
+
+// validShareCardTheme 判断卡片主题是否合法
+func validShareCardTheme(theme string) bool {
+	_, ok := shareCardThemes[theme]
+	return ok
+}
+
+// shareCardData 绘制分享卡片所需的记录数据
+type shareCardData struct {
+	SportName string // 运动类型名称
+	Icon      string // 运动类型图标名
+	Headline  string // 标题，优先使用具体项目名称
+	Username  string
+	StartTime time.Time
+	Duration  int64   // 分钟
+	Distance  float64 // 公里
+	Calories  int64
+}
+
+// ShareCardRenderer 分享卡片绘制器，字体加载后只读；绘制结果按卡片内容缓存
+type ShareCardRenderer struct {
+	textFonts   []*sfnt.Font // 文字字体，按顺序选择第一个包含该字符的字体
+	numberFonts []*sfnt.Font // 数值字体
+	iconDir     string
+	footer      string
+
+	mu    sync.Mutex
+	cache map[string][]byte
+	order []string // 缓存写入顺序，超出容量时淘汰最早的卡片
+}
+
+// ErrShareCardUnavailable 分享卡片字体未加载，暂时无法生成卡片
+var ErrShareCardUnavailable = errors.New("分享卡片暂不可用")
+
+var (
+	defaultShareCardRenderer   *ShareCardRenderer
+	defaultShareCardRendererMu sync.Mutex
+)
+
+// GetShareCardRenderer 获取全局分享卡片绘制器，按配置加载字体
+// 内置字体不包含中文，中文字体加载失败时返回 ErrShareCardUnavailable，避免卡片中的中文全部显示为缺字方框；
+// 加载失败不会缓存，补充字体文件后下次调用即可恢复，无需重启服务
+// 运动类型图标可选，缺少图标文件时显示运动类型的首字
+func GetShareCardRenderer() (*ShareCardRenderer, error) {
+	defaultShareCardRendererMu.Lock()
+	defer defaultShareCardRendererMu.Unlock()
+	if defaultShareCardRenderer != nil {
+		return defaultShareCardRenderer, nil
+	}
+
+	cfg := config.LoadShareConfig()
+	cjk, err := loadCardFont(cfg.CardFontPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: 加载中文字体 %s 失败，可通过 SHARE_CARD_FONT 指定字体文件: %v", ErrShareCardUnavailable, cfg.CardFontPath, err)
+	}
+	regular, _ := opentype.Parse(goregular.TTF)
+	bold, _ := opentype.Parse(gobold.TTF)
+
+	r := &ShareCardRenderer{
+		textFonts:   []*sfnt.Font{cjk, regular},
+		numberFonts: []*sfnt.Font{bold, cjk},
+		iconDir:     cfg.CardIconDir,
+		footer:      cfg.BaseURL,
+		cache:       make(map[string][]byte),
+	}
+	if u, err := url.Parse(cfg.BaseURL); err == nil && u.Host != "" {
+		r.footer = u.Host
+	}
+	defaultShareCardRenderer = r
+	return r, nil
+}
+
+// loadCardFont 加载字体文件，字体集合（ttc/otc）取第一个字体，字体需要包含中文字符
+func loadCardFont(path string) (*sfnt.Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := opentype.ParseCollection(data)
+	if err != nil {
+		return nil, err
+	}
+	f, err := collection.Font(0)
+	if err != nil {
+		return nil, err
+	}
+	if glyph, err := f.GlyphIndex(&sfnt.Buffer{}, '中'); err != nil || glyph == 0 {
+		return nil, errors.New("字体不包含中文字符")
+	}
+	return f, nil
+}
+
+// Render 获取分享卡片 PNG，key 相同时直接返回缓存
+func (r *ShareCardRenderer) Render(key string, data shareCardData, theme string) ([]byte, error) {
+	r.mu.Lock()
+	cached, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	img, err := r.draw(data, shareCardThemes[theme])
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.cache[key]; !ok {
+		if len(r.order) >= shareCardCacheSize {
+			delete(r.cache, r.order[0])
+			r.order = r.order[1:]
+		}
+		r.order = append(r.order, key)
+	}
+	r.cache[key] = buf.Bytes()
+	return buf.Bytes(), nil
+}
+
+// draw 绘制卡片：顶部为运动图标、标题和日期，中部为数据面板，底部为用户名和站点
+func (r *ShareCardRenderer) draw(data shareCardData, theme shareCardTheme) (*image.RGBA, error) {
+	img := image.NewRGBA(image.Rect(0, 0, shareCardWidth, shareCardHeight))
+	fillGradient(img, theme.top, theme.bottom)
+
+	title, err := newCardText(r.textFonts, 44)
+	if err != nil {
+		return nil, err
+	}
+	body, err := newCardText(r.textFonts, 28)
+	if err != nil {
+		return nil, err
+	}
+	number, err := newCardText(r.numberFonts, 72)
+	if err != nil {
+		return nil, err
+	}
+
+	// 运动类型图标
+	iconRect := image.Rect(shareCardMargin, 50, shareCardMargin+shareCardIconSize, 50+shareCardIconSize)
+	fillRoundRect(img, iconRect, shareCardIconSize/2, theme.accent)
+	if icon := r.loadIcon(data.Icon); icon != nil {
+		inset := iconRect.Inset(shareCardIconSize / 6)
+		xdraw.CatmullRom.Scale(img, inset, icon, icon.Bounds(), draw.Over, nil)
+	} else if initial := []rune(data.SportName); len(initial) > 0 {
+		// 没有图标文件时显示运动类型的首字
+		label, err := newCardText(r.textFonts, 56)
+		if err != nil {
+			return nil, err
+		}
+		s := string(initial[0])
+		x := iconRect.Min.X + (shareCardIconSize-label.width(s))/2
+		label.draw(img, x, iconRect.Min.Y+shareCardIconSize/2+20, s, theme.top)
+	}
+
+	// 标题和日期
+	textX := iconRect.Max.X + 32
+	textWidth := shareCardWidth - shareCardMargin - textX
+	title.draw(img, textX, 100, title.truncate(data.Headline, textWidth), theme.text)
+	body.draw(img, textX, 150, data.StartTime.Format("2006年01月02日 15:04"), theme.muted)
+
+	// 数据面板
+	panel := image.Rect(shareCardMargin, 210, shareCardWidth-shareCardMargin, 430)
+	fillRoundRect(img, panel, 24, theme.panel)
+	stats := make([][2]string, 0, 3)
+	if data.Distance > 0 {
+		stats = append(stats, [2]string{strconv.FormatFloat(data.Distance, 'f', 2, 64), "公里"})
+	}
+	stats = append(stats, [2]string{strconv.FormatInt(data.Duration, 10), "分钟"})
+	if data.Calories > 0 {
+		stats = append(stats, [2]string{strconv.FormatInt(data.Calories, 10), "千卡"})
+	}
+	column := panel.Dx() / len(stats)
+	for i, stat := range stats {
+		center := panel.Min.X + column*i + column/2
+		number.draw(img, center-number.width(stat[0])/2, panel.Min.Y+120, stat[0], theme.accent)
+		body.draw(img, center-body.width(stat[1])/2, panel.Min.Y+175, stat[1], theme.muted)
+	}
+
+	// 底部
+	fillRoundRect(img, image.Rect(shareCardMargin, 478, shareCardWidth-shareCardMargin, 480), 0, theme.muted)
+	footerWidth := body.width(r.footer)
+	body.draw(img, shareCardMargin, 540,
+		body.truncate("@"+data.Username, shareCardWidth-2*shareCardMargin-footerWidth-32), theme.text)
+	body.draw(img, shareCardWidth-shareCardMargin-footerWidth, 540, r.footer, theme.muted)
+	return img, nil
+}
+
+// loadIcon 读取运动类型图标，文件不存在或无法解析时返回 nil
+func (r *ShareCardRenderer) loadIcon(name string) image.Image {
+	if name == "" || filepath.Base(name) != name {
+		return nil
+	}
+	file, err := os.Open(filepath.Join(r.iconDir, name+".png"))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	icon, err := png.Decode(file)
+	if err != nil {
+		log.Printf("解析运动图标 %s 失败: %v", name, err)
+		return nil
+	}
+	return icon
+}
+
+// cardText 按字号绘制文字，逐字选择包含该字符的字体，实现中英文混排
+type cardText struct {
+	fonts []*sfnt.Font
+	faces []font.Face
+	buf   sfnt.Buffer
+}
+
+// newCardText 创建指定字号（像素）的文字绘制器，仅在单次绘制中使用，不可并发
+func newCardText(fonts []*sfnt.Font, size float64) (*cardText, error) {
+	t := &cardText{fonts: fonts}
+	for _, f := range fonts {
+		face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return nil, fmt.Errorf("创建字体失败: %w", err)
+		}
+		t.faces = append(t.faces, face)
+	}
+	return t, nil
+}
+
+// face 选择第一个包含字符 ch 的字体，都不包含时使用首选字体
+func (t *cardText) face(ch rune) font.Face {
+	for i, f := range t.fonts {
+		if index, err := f.GlyphIndex(&t.buf, ch); err == nil && index != 0 {
+			return t.faces[i]
+		}
+	}
+	return t.faces[0]
+}
+
+// width 文字宽度（像素）
+func (t *cardText) width(s string) int {
+	var w fixed.Int26_6
+	for _, ch := range s {
+		if advance, ok := t.face(ch).GlyphAdvance(ch); ok {
+			w += advance
+		}
+	}
+	return w.Ceil()
+}
+
+// truncate 截断超出宽度的文字，末尾加省略号
+func (t *cardText) truncate(s string, maxWidth int) string {
+	if t.width(s) <= maxWidth {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if candidate := string(runes) + "…"; t.width(candidate) <= maxWidth {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// draw 以 (x, baseline) 为起点绘制一行文字
+func (t *cardText) draw(dst draw.Image, x, baseline int, s string, c color.Color) {
+	d := font.Drawer{Dst: dst, Src: image.NewUniform(c), Dot: fixed.P(x, baseline)}
+	for _, ch := range s {
+		d.Face = t.face(ch)
+		d.DrawString(string(ch))
+	}
+}
+
+// fillGradient 用上下渐变色填充整张图片
+func fillGradient(img *image.RGBA, top, bottom color.RGBA) {
+	bounds := img.Bounds()
+	height := bounds.Dy()
+	lerp := func(a, b uint8, y int) uint8 {
+		return uint8((int(a)*(height-1-y) + int(b)*y) / (height - 1))
+	}
+	for y := 0; y < height; y++ {
+		c := color.RGBA{lerp(top.R, bottom.R, y), lerp(top.G, bottom.G, y), lerp(top.B, bottom.B, y), 0xFF}
+		row := image.Rect(bounds.Min.X, bounds.Min.Y+y, bounds.Max.X, bounds.Min.Y+y+1)
+		draw.Draw(img, row, image.NewUniform(c), image.Point{}, draw.Src)
+	}
+}
+
+// fillRoundRect 填充抗锯齿的圆角矩形，radius 为矩形短边的一半时即为圆形
+func fillRoundRect(img *image.RGBA, rect image.Rectangle, radius int, c color.Color) {
+	x0, y0 := float32(rect.Min.X), float32(rect.Min.Y)
+	x1, y1 := float32(rect.Max.X), float32(rect.Max.Y)
+	rad := float32(radius)
+	k := rad * 0.5523 // 三次贝塞尔曲线近似四分之一圆的控制点距离
+
+	z := vector.NewRasterizer(img.Bounds().Dx(), img.Bounds().Dy())
+	z.MoveTo(x0+rad, y0)
+	z.LineTo(x1-rad, y0)
+	z.CubeTo(x1-rad+k, y0, x1, y0+rad-k, x1, y0+rad)
+	z.LineTo(x1, y1-rad)
+	z.CubeTo(x1, y1-rad+k, x1-rad+k, y1, x1-rad, y1)
+	z.LineTo(x0+rad, y1)
+	z.CubeTo(x0+rad-k, y1, x0, y1-rad+k, x0, y1-rad)
+	z.LineTo(x0, y0+rad)
+	z.CubeTo(x0, y0+rad-k, x0+rad-k, y0, x0+rad, y0)
+	z.ClosePath()
+	z.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{})
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// StreamTicketTTL 推送连接票据有效期，连接断开后票据在该时长内仍可用于重连
+const StreamTicketTTL = 60 * time.Second
+
+// streamTicket 推送连接票据
+type streamTicket struct {
+	userID      int64
+	sessionID   uint64
+	expiresAt   time.Time
+	connections int // 使用该票据的连接数，有连接时票据不过期
+}
+
+// StreamTicketStore 推送连接票据存储
+// 浏览器 EventSource 无法设置请求头，客户端先用 token 换取短期票据，再通过查询参数建立连接；
+// EventSource 断线后会用同一地址自动重连，因此票据在连接期间及断开后的有效期内可重复使用
+type StreamTicketStore struct {
+	mu      sync.Mutex
+	tickets map[string]*streamTicket
+}
+
+var (
+	defaultTicketStore     *StreamTicketStore
+	defaultTicketStoreOnce sync.Once
+)
+
+// GetStreamTicketStore 获取全局票据存储
+func GetStreamTicketStore() *StreamTicketStore {
+	defaultTicketStoreOnce.Do(func() {
+		defaultTicketStore = &StreamTicketStore{tickets: make(map[string]*streamTicket)}
+	})
+	return defaultTicketStore
+}
+
+// Issue 为登录会话签发票据
+func (s *StreamTicketStore) Issue(userID int64, sessionID uint64) (string, time.Time, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, err
+	}
+	ticket := hex.EncodeToString(buf)
+	expiresAt := time.Now().Add(StreamTicketTTL)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.purge()
+	s.tickets[ticket] = &streamTicket{userID: userID, sessionID: sessionID, expiresAt: expiresAt}
+	return ticket, expiresAt, nil
+}
+
+// Redeem 使用票据建立连接，返回票据所属的用户和会话；连接断开后需调用 Release
+func (s *StreamTicketStore) Redeem(ticket string) (int64, uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tickets[ticket]
+	if !ok {
+		return 0, 0, false
+	}
+	if t.connections == 0 && time.Now().After(t.expiresAt) {
+		delete(s.tickets, ticket)
+		return 0, 0, false
+	}
+	t.connections++
+	return t.userID, t.sessionID, true
+}
+
+// Release 连接断开，票据从此刻起在有效期内仍可用于重连
+func (s *StreamTicketStore) Release(ticket string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tickets[ticket]
+	if !ok {
+		return
+	}
+	if t.connections > 0 {
+		t.connections--
+	}
+	t.expiresAt = time.Now().Add(StreamTicketTTL)
+}
+
+// purge 清理没有连接且已过期的票据，调用方需持有锁
+func (s *StreamTicketStore) purge() {
+	now := time.Now()
+	for ticket, t := range s.tickets {
+		if t.connections == 0 && now.After(t.expiresAt) {
+			delete(s.tickets, ticket)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"sports-app/backend/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StrengthService 力量训练服务（动作库与进步曲线）
+type StrengthService struct {
+	db *gorm.DB
+}
+
+// NewStrengthService 创建力量训练服务实例
+func NewStrengthService(db *gorm.DB) *StrengthService {
+	return &StrengthService{db: db}
+}
+
+// GetExercises 获取系统内置动作及用户自定义动作
+func (s *StrengthService) GetExercises(userID int64, category string) ([]models.StrengthExercise, error) {
+	query := s.db.Where("user_id IS NULL OR user_id = ?", userID)
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	var exercises []models.StrengthExercise
+	if err := query.Order("category ASC, name ASC").Find(&exercises).Error; err != nil {
+		return nil, err
+	}
+	return exercises, nil
+}
+
+// CreateExercise 创建用户自定义动作
+func (s *StrengthService) CreateExercise(userID int64, exercise *models.StrengthExercise) error {
+	exercise.Name = strings.TrimSpace(exercise.Name)
+	if exercise.Name == "" {
+		return errors.New("动作名称不能为空")
+	}
+
+	var count int64
+	if err := s.db.Model(&models.StrengthExercise{}).
+		Where("name = ? AND (user_id IS NULL OR user_id = ?)", exercise.Name, userID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("动作已存在")
+	}
+
+	exercise.ID = 0
+	exercise.UserID = &userID
+	return s.db.Create(exercise).Error
+}
+
+// DeleteExercise 删除用户自定义动作，系统内置动作不可删除
+func (s *StrengthService) DeleteExercise(userID, id int64) error {
+	result := s.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.StrengthExercise{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetProgression 获取某个动作在时间范围内的进步曲线，每条运动记录对应一个数据点
+func (s *StrengthService) GetProgression(userID, exerciseID int64, timeRange string) ([]models.ProgressionPoint, error) {
+	query := s.db.Table("record_sets").
+		Select("record_sets.record_id, record_sets.reps, record_sets.weight, sport_records.start_time").
+		Joins("JOIN sport_records ON sport_records.id = record_sets.record_id").
+		Where("sport_records.user_id = ? AND record_sets.strength_exercise_id = ?", userID, exerciseID)
+
+	now := time.Now()
+	switch timeRange {
+	case "month":
+		query = query.Where("sport_records.start_time >= ?", now.AddDate(0, -1, 0))
+	case "quarter":
+		query = query.Where("sport_records.start_time >= ?", now.AddDate(0, -3, 0))
+	case "year":
+		query = query.Where("sport_records.start_time >= ?", now.AddDate(-1, 0, 0))
+	}
+
+	var rows []struct {
+		RecordID  int64
+		Reps      int
+		Weight    float64
+		StartTime time.Time
+	}
+	if err := query.Order("sport_records.start_time ASC, record_sets.record_id ASC, record_sets.set_index ASC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	points := make([]models.ProgressionPoint, 0)
+	for _, row := range rows {
+		if len(points) == 0 || points[len(points)-1].RecordID != row.RecordID {
+			points = append(points, models.ProgressionPoint{RecordID: row.RecordID, Date: row.StartTime})
+		}
+
+		set := models.RecordSet{Reps: row.Reps, Weight: row.Weight}
+		point := &points[len(points)-1]
+		point.TotalVolume += set.Volume()
+		if set.Weight > point.MaxWeight {
+			point.MaxWeight = set.Weight
+		}
+		if oneRM := set.EstimatedOneRM(); oneRM > point.EstimatedOneRM {
+			point.EstimatedOneRM = oneRM
+		}
+	}
+	return points, nil
+}
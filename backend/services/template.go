@@ -0,0 +1,181 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sports-app/backend/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidTemplate 训练模板参数校验失败
+var ErrInvalidTemplate = errors.New("无效的训练模板")
+
+// TemplateOverrides 使用模板创建记录时可覆盖的字段
+type TemplateOverrides struct {
+	StartTime *time.Time `json:"start_time"`
+	Duration  *int64     `json:"duration"`
+	Calories  *int64     `json:"calories"`
+	Notes     *string    `json:"notes"`
+}
+
+// TemplateService 训练模板服务
+type TemplateService struct {
+	db *gorm.DB
+}
+
+// NewTemplateService 创建训练模板服务实例
+func NewTemplateService(db *gorm.DB) *TemplateService {
+	return &TemplateService{db: db}
+}
+
+// GetTemplates 获取用户的训练模板，按使用频率排序
+func (s *TemplateService) GetTemplates(userID int64) ([]models.WorkoutTemplate, error) {
+	var templates []models.WorkoutTemplate
+	if err := s.db.Preload("SportType").
+		Where("user_id = ?", userID).
+		Order("usage_count DESC, last_used_at DESC, id DESC").
+		Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// CreateTemplate 创建训练模板
+func (s *TemplateService) CreateTemplate(template *models.WorkoutTemplate) error {
+	if err := validateTemplate(template); err != nil {
+		return err
+	}
+	template.ID = 0
+	template.UsageCount = 0
+	template.LastUsedAt = nil
+	return s.db.Create(template).Error
+}
+
+// CreateTemplateFromRecord 将已有运动记录保存为训练模板
+func (s *TemplateService) CreateTemplateFromRecord(userID, recordID int64, name string) (*models.WorkoutTemplate, error) {
+	var record models.SportRecord
+	if err := s.db.Where("id = ? AND user_id = ?", recordID, userID).First(&record).Error; err != nil {
+		return nil, err
+	}
+
+	template := &models.WorkoutTemplate{
+		UserID:      userID,
+		Name:        name,
+		SportTypeID: record.SportTypeID,
+		Exercise:    record.Exercise,
+		Duration:    record.Duration,
+		Calories:    record.Calories,
+		Notes:       record.Notes,
+	}
+	if err := s.CreateTemplate(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// UpdateTemplate 更新训练模板
+func (s *TemplateService) UpdateTemplate(template *models.WorkoutTemplate) error {
+	if err := validateTemplate(template); err != nil {
+		return err
+	}
+	result := s.db.Model(&models.WorkoutTemplate{}).
+		Where("id = ? AND user_id = ?", template.ID, template.UserID).
+		Updates(map[string]interface{}{
+			"name":          template.Name,
+			"sport_type_id": template.SportTypeID,
+			"exercise":      template.Exercise,
+			"duration":      template.Duration,
+			"calories":      template.Calories,
+			"notes":         template.Notes,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteTemplate 删除训练模板
+func (s *TemplateService) DeleteTemplate(userID, id int64) error {
+	result := s.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.WorkoutTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateRecordFromTemplate 使用模板创建运动记录，默认开始时间为当前时间，并累加模板使用次数
+func (s *TemplateService) CreateRecordFromTemplate(userID, templateID int64, overrides TemplateOverrides) (*models.SportRecord, error) {
+	var template models.WorkoutTemplate
+	if err := s.db.Where("id = ? AND user_id = ?", templateID, userID).First(&template).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	record := &models.SportRecord{
+		UserID:      userID,
+		SportTypeID: template.SportTypeID,
+		Exercise:    template.Exercise,
+		Duration:    template.Duration,
+		Calories:    template.Calories,
+		Notes:       template.Notes,
+		StartTime:   now,
+	}
+	if overrides.StartTime != nil {
+		record.StartTime = *overrides.StartTime
+	}
+	if overrides.Duration != nil {
+		record.Duration = *overrides.Duration
+	}
+	if overrides.Calories != nil {
+		record.Calories = *overrides.Calories
+	}
+	if overrides.Notes != nil {
+		record.Notes = *overrides.Notes
+	}
+	record.EndTime = record.StartTime.Add(time.Duration(record.Duration) * time.Minute)
+
+	if err := validateRecord(record); err != nil {
+		return nil, err
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := createRecord(tx, record); err != nil {
+			return err
+		}
+		return tx.Model(&models.WorkoutTemplate{}).Where("id = ?", template.ID).Updates(map[string]interface{}{
+			"usage_count":  gorm.Expr("usage_count + 1"),
+			"last_used_at": now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// validateTemplate 校验训练模板
+func validateTemplate(template *models.WorkoutTemplate) error {
+	template.Name = strings.TrimSpace(template.Name)
+	if template.Name == "" {
+		return fmt.Errorf("%w: 模板名称不能为空", ErrInvalidTemplate)
+	}
+	if len([]rune(template.Name)) > 50 {
+		return fmt.Errorf("%w: 模板名称不能超过50个字符", ErrInvalidTemplate)
+	}
+	if template.SportTypeID <= 0 {
+		return fmt.Errorf("%w: 请选择运动类型", ErrInvalidTemplate)
+	}
+	if template.Duration < 0 || template.Calories < 0 {
+		return fmt.Errorf("%w: 时长和卡路里不能为负数", ErrInvalidTemplate)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"path/filepath"
+	"sports-app/backend/models"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB 创建测试用的 SQLite 数据库并建好社区相关的表，每个测试使用独立的数据库文件
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger:                                   logger.Default.LogMode(logger.Silent),
+		DisableForeignKeyConstraintWhenMigrating: true,
+	})
+	if err != nil {
+		t.Fatalf("打开测试数据库失败: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&models.User{},
+		&models.SportType{},
+		&models.Follow{},
+		&models.UserBlock{},
+		&models.UserMute{},
+		&models.CheckIn{},
+		&models.Comment{},
+		&models.Like{},
+		&models.Hashtag{},
+		&models.CheckInHashtag{},
+		&models.CommentHashtag{},
+		&models.Notification{},
+		&models.NotificationActor{},
+		&models.ModerationReview{},
+		&models.Conversation{},
+		&models.ConversationMember{},
+		&models.Message{},
+	); err != nil {
+		t.Fatalf("创建测试表失败: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// createTestUser 创建测试用户，跳过密码加密钩子
+func createTestUser(t *testing.T, db *gorm.DB, username string) int64 {
+	t.Helper()
+	user := &models.User{Username: username, Password: "x", Email: username + "@example.com"}
+	if err := db.Session(&gorm.Session{SkipHooks: true}).Create(user).Error; err != nil {
+		t.Fatalf("创建用户 %s 失败: %v", username, err)
+	}
+	return user.ID
+}
+
+// createTestSportType 创建测试用的运动类型
+func createTestSportType(t *testing.T, db *gorm.DB) uint64 {
+	t.Helper()
+	sportType := &models.SportType{Name: "跑步"}
+	if err := db.Create(sportType).Error; err != nil {
+		t.Fatalf("创建运动类型失败: %v", err)
+	}
+	return uint64(sportType.ID)
+}
+
+// createTestCheckIn 以指定可见范围发布打卡
+func createTestCheckIn(t *testing.T, db *gorm.DB, userID int64, sportTypeID uint64, visibility string) uint64 {
+	t.Helper()
+	checkIn, err := NewCommunityService(db).CreateCheckIn(userID, CreateCheckInInput{
+		SportTypeID: sportTypeID,
+		Description: "晨跑打卡",
+		Visibility:  visibility,
+	})
+	if err != nil {
+		t.Fatalf("用户 %d 发布打卡失败: %v", userID, err)
+	}
+	return checkIn.ID
+}
+
+// checkInIDs 提取打卡ID
+func checkInIDs(checkIns []models.CheckIn) map[uint64]bool {
+	ids := make(map[uint64]bool, len(checkIns))
+	for _, checkIn := range checkIns {
+		ids[checkIn.ID] = true
+	}
+	return ids
+}
@@ -0,0 +1,283 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sports-app/backend/config"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSABits RSA 密钥的最小长度
+const minRSABits = 2048
+
+// ErrUnknownKey 令牌的 kid 不在校验密钥中，可能已被轮换移除
+var ErrUnknownKey = errors.New("未知的签名密钥")
+
+// compromisedKIDs 曾经提交到代码仓库的密钥指纹，任何人都能用对应的私钥签发令牌，既不能签名也不能校验
+var compromisedKIDs = map[string]bool{
+	"biAsTSM4wjiOj18CwoRehF-EC9rcH10xVELuH5pl8lg": true, // config/keys/private.pem
+	"54tcOr5ZcuWndAoqVx276KyNluqOyQBAoszhjbSZFcE": true, // scripts/config/keys/private.pem
+}
+
+// Claims 访问令牌声明
+type Claims struct {
+	UserID    int64  `json:"user_id"`
+	SessionID uint64 `json:"sid"` // 所属登录会话，会话吊销后令牌立即失效
+	jwt.RegisteredClaims
+}
+
+// JWK 公钥的 JSON Web Key 表示
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`   // RSA 模数
+	E   string `json:"e,omitempty"`   // RSA 公钥指数
+	Crv string `json:"crv,omitempty"` // Ed25519 曲线
+	X   string `json:"x,omitempty"`   // Ed25519 公钥
+}
+
+// JWKS 公钥集合，供其他服务校验令牌
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// verifyKey 校验密钥
+type verifyKey struct {
+	method jwt.SigningMethod
+	public crypto.PublicKey
+	jwk    JWK
+}
+
+// Manager 令牌管理器，构建后只读，可并发使用
+// 按签名私钥的类型选择 RS256 或 EdDSA，令牌头部的 kid 为密钥的 JWK 指纹；
+// 轮换密钥时旧公钥继续用于校验，直到旧令牌全部过期
+type Manager struct {
+	signingKID string
+	signer     crypto.Signer
+	keys       map[string]*verifyKey
+	order      []string // 公钥发布顺序，当前签名密钥在最前
+}
+
+var (
+	defaultManager     *Manager
+	defaultManagerErr  error
+	defaultManagerOnce sync.Once
+)
+
+// Default 获取全局令牌管理器，首次调用时按配置加载密钥
+func Default() (*Manager, error) {
+	defaultManagerOnce.Do(func() {
+		cfg := config.GetConfig().JWT
+		defaultManager, defaultManagerErr = Load(cfg.PrivateKeyPath, cfg.PublicKeyPath)
+	})
+	return defaultManager, defaultManagerErr
+}
+
+// Load 从 PEM 文件加载签名私钥和校验公钥
+// 私钥支持 PKCS#8 格式的 RSA、Ed25519 私钥和 PKCS#1 格式的 RSA 私钥；公钥文件不存在时只使用签名私钥对应的公钥校验
+func Load(privateKeyPath, publicKeyPath string) (*Manager, error) {
+	if privateKeyPath == "" {
+		return nil, errors.New("未配置签名私钥 JWT_PRIVATE_KEY_PATH")
+	}
+	data, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("签名私钥 %s 不存在，请先运行 scripts/generate_keys.sh 生成密钥", privateKeyPath)
+		}
+		return nil, fmt.Errorf("读取私钥失败: %w", err)
+	}
+	signer, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var publics []crypto.PublicKey
+	if publicKeyPath != "" {
+		data, err := os.ReadFile(publicKeyPath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("读取公钥失败: %w", err)
+		}
+		if publics, err = parsePublicKeys(data); err != nil {
+			return nil, err
+		}
+	}
+	return NewManager(signer, publics...)
+}
+
+// NewManager 创建令牌管理器，signer 为当前签名密钥，publics 为轮换期间仍然有效的其他公钥
+func NewManager(signer crypto.Signer, publics ...crypto.PublicKey) (*Manager, error) {
+	m := &Manager{signer: signer, keys: make(map[string]*verifyKey)}
+	signing, err := m.addKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	m.signingKID = signing.jwk.Kid
+	for _, public := range publics {
+		if _, err := m.addKey(public); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Sign 签发令牌
+func (m *Manager) Sign(claims *Claims) (string, error) {
+	key := m.keys[m.signingKID]
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = m.signingKID
+	return token.SignedString(m.signer)
+}
+
+// Parse 校验并解析令牌，按 kid 选择校验密钥，签名算法必须与密钥类型一致
+func (m *Manager) Parse(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := m.keys[kid]
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+		if t.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("签名算法 %s 与密钥不匹配", t.Method.Alg())
+		}
+		return key.public, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("无效的token")
+	}
+	return claims, nil
+}
+
+// JWKS 返回所有校验公钥
+func (m *Manager) JWKS() JWKS {
+	keys := make([]JWK, 0, len(m.order))
+	for _, kid := range m.order {
+		keys = append(keys, m.keys[kid].jwk)
+	}
+	return JWKS{Keys: keys}
+}
+
+// addKey 添加校验公钥，重复的公钥只保留一份，已泄露的密钥直接拒绝
+func (m *Manager) addKey(public crypto.PublicKey) (*verifyKey, error) {
+	key, err := newVerifyKey(public)
+	if err != nil {
+		return nil, err
+	}
+	if compromisedKIDs[key.jwk.Kid] {
+		return nil, fmt.Errorf("密钥 %s 曾提交到代码仓库，已不安全，请重新生成密钥", key.jwk.Kid)
+	}
+	if existing, ok := m.keys[key.jwk.Kid]; ok {
+		return existing, nil
+	}
+	m.keys[key.jwk.Kid] = key
+	m.order = append(m.order, key.jwk.Kid)
+	return key, nil
+}
+
+// newVerifyKey 根据公钥类型确定签名算法并生成 JWK，kid 为 RFC 7638 定义的 JWK 指纹
+func newVerifyKey(public crypto.PublicKey) (*verifyKey, error) {
+	var key *verifyKey
+	var thumbprint string
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("RSA 密钥长度不能少于%d位", minRSABits)
+		}
+		n := base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		key = &verifyKey{
+			method: jwt.SigningMethodRS256,
+			public: pub,
+			jwk:    JWK{Kty: "RSA", Use: "sig", Alg: jwt.SigningMethodRS256.Alg(), N: n, E: e},
+		}
+		thumbprint = fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, e, n)
+	case ed25519.PublicKey:
+		x := base64.RawURLEncoding.EncodeToString(pub)
+		key = &verifyKey{
+			method: jwt.SigningMethodEdDSA,
+			public: pub,
+			jwk:    JWK{Kty: "OKP", Use: "sig", Alg: jwt.SigningMethodEdDSA.Alg(), Crv: "Ed25519", X: x},
+		}
+		thumbprint = fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":%q}`, x)
+	default:
+		return nil, fmt.Errorf("不支持的密钥类型 %T，只支持 RSA 和 Ed25519", public)
+	}
+
+	sum := sha256.Sum256([]byte(thumbprint))
+	key.jwk.Kid = base64.RawURLEncoding.EncodeToString(sum[:])
+	return key, nil
+}
+
+// parsePrivateKey 解析 PEM 格式的私钥
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("私钥不是有效的 PEM 格式")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("不支持的私钥类型 %s", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("解析私钥失败: %w", err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("不支持的私钥类型 %T，只支持 RSA 和 Ed25519", key)
+	}
+}
+
+// parsePublicKeys 解析文件中的所有 PEM 公钥
+func parsePublicKeys(data []byte) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return keys, nil
+		}
+
+		var key interface{}
+		var err error
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		default:
+			return nil, fmt.Errorf("不支持的公钥类型 %s", block.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析公钥失败: %w", err)
+		}
+		keys = append(keys, key)
+	}
+}
@@ -0,0 +1,95 @@
+package utils
+
+// ACMatch 一次匹配结果，Start/End 为匹配在输入中的下标区间 [Start, End)
+type ACMatch struct {
+	Pattern int
+	Start   int
+	End     int
+}
+
+// acNode 自动机节点
+type acNode struct {
+	next    map[rune]int
+	fail    int
+	outputs []int // 以该节点结尾的模式下标（含失败链上的模式）
+}
+
+// ACMatcher Aho-Corasick 多模式匹配自动机，构建后只读，可并发使用
+type ACMatcher struct {
+	nodes   []acNode
+	lengths []int
+}
+
+// NewACMatcher 根据模式串构建自动机，模式下标即参数中的顺序
+func NewACMatcher(patterns [][]rune) *ACMatcher {
+	m := &ACMatcher{
+		nodes:   []acNode{{next: make(map[rune]int)}},
+		lengths: make([]int, len(patterns)),
+	}
+
+	for i, pattern := range patterns {
+		m.lengths[i] = len(pattern)
+		if len(pattern) == 0 {
+			continue
+		}
+		cur := 0
+		for _, r := range pattern {
+			nxt, ok := m.nodes[cur].next[r]
+			if !ok {
+				nxt = len(m.nodes)
+				m.nodes = append(m.nodes, acNode{next: make(map[rune]int)})
+				m.nodes[cur].next[r] = nxt
+			}
+			cur = nxt
+		}
+		m.nodes[cur].outputs = append(m.nodes[cur].outputs, i)
+	}
+
+	// 按层次遍历计算失败指针
+	queue := make([]int, 0, len(m.nodes))
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[cur].next {
+			fail := m.nodes[cur].fail
+			for fail != 0 {
+				if _, ok := m.nodes[fail].next[r]; ok {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			if target, ok := m.nodes[fail].next[r]; ok && target != child {
+				m.nodes[child].fail = target
+			}
+			failOutputs := m.nodes[m.nodes[child].fail].outputs
+			m.nodes[child].outputs = append(m.nodes[child].outputs, failOutputs...)
+			queue = append(queue, child)
+		}
+	}
+	return m
+}
+
+// FindAll 返回文本中所有（可重叠的）匹配
+func (m *ACMatcher) FindAll(text []rune) []ACMatch {
+	var matches []ACMatch
+	cur := 0
+	for i, r := range text {
+		for {
+			if nxt, ok := m.nodes[cur].next[r]; ok {
+				cur = nxt
+				break
+			}
+			if cur == 0 {
+				break
+			}
+			cur = m.nodes[cur].fail
+		}
+		for _, p := range m.nodes[cur].outputs {
+			matches = append(matches, ACMatch{Pattern: p, Start: i + 1 - m.lengths[p], End: i + 1})
+		}
+	}
+	return matches
+}
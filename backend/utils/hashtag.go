@@ -0,0 +1,143 @@
+package utils
+
+import "unicode"
+
+// 话题和提及的限制
+const (
+	MaxHashtagLength  = 20 // 话题名最多字符数
+	MaxMentionLength  = 30 // 提及的用户名最多字符数
+	maxMarkersPerText = 10 // 单条内容最多解析的话题或提及数
+)
+
+// isHashMark 判断是否为话题标记，兼容中文输入法的全角 ＃
+func isHashMark(r rune) bool {
+	return r == '#' || r == '＃'
+}
+
+// isAtMark 判断是否为提及标记，兼容中文输入法的全角 ＠
+func isAtMark(r rune) bool {
+	return r == '@' || r == '＠'
+}
+
+// isTagRune 话题中允许的字符：字母（含中文）、数字、下划线
+func isTagRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// isMentionRune 提及的用户名中允许的字符，在话题字符的基础上允许连字符
+func isMentionRune(r rune) bool {
+	return isTagRune(r) || r == '-'
+}
+
+// markerAllowed 标记前紧跟英文字母或数字时不视为标记，避免把邮箱、C# 之类的内容解析为提及或话题
+// 中文与标记之间不需要空格，如“今天跑步#晨跑#”
+func markerAllowed(runes []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev := runes[i-1]
+	return prev > unicode.MaxASCII || !(unicode.IsLetter(prev) || unicode.IsDigit(prev) || prev == '_')
+}
+
+// NormalizeHashtag 规范化话题名：去掉开头的 #，逐字规范化（全角转半角、大写转小写、繁体转简体）
+// 话题名为空、过长、包含不允许的字符或不含任何字母时返回 false
+func NormalizeHashtag(name string) (string, bool) {
+	runes := []rune(name)
+	if len(runes) > 0 && isHashMark(runes[0]) {
+		runes = runes[1:]
+	}
+	if len(runes) == 0 || len(runes) > MaxHashtagLength {
+		return "", false
+	}
+
+	hasLetter := false
+	normalized := make([]rune, len(runes))
+	for i, r := range runes {
+		if !isTagRune(r) {
+			return "", false
+		}
+		if unicode.IsLetter(r) {
+			hasLetter = true
+		}
+		normalized[i] = NormalizeRune(r)
+	}
+	if !hasLetter {
+		return "", false
+	}
+	return string(normalized), true
+}
+
+// ParseHashtags 解析文本中的话题，返回去重后的规范化话题名
+// 支持两种写法：#话题#，以及 #话题 后接空格、标点或下一个标记
+// 中文内容没有空格分隔时应使用 #话题# 的写法，否则话题会一直延续到标点为止
+func ParseHashtags(text string) []string {
+	runes := []rune(text)
+	tags := make([]string, 0)
+	seen := make(map[string]bool)
+	for i := 0; i < len(runes) && len(tags) < maxMarkersPerText; i++ {
+		if !isHashMark(runes[i]) || !markerAllowed(runes, i) {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && isTagRune(runes[end]) {
+			end++
+		}
+		if end == i+1 {
+			continue
+		}
+
+		name := string(runes[i+1 : end])
+		// #话题# 写法消费掉结尾的 #
+		if end < len(runes) && isHashMark(runes[end]) {
+			i = end
+		} else {
+			i = end - 1
+		}
+		if tag, ok := NormalizeHashtag(name); ok && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// ParseMentions 解析文本中 @ 后面的候选用户名，返回去重后的结果
+// 中文内容中用户名后面可能紧跟正文，如“@小明一起跑步”，候选用户名需要由调用方按已有用户名的最长前缀确定
+func ParseMentions(text string) []string {
+	runes := []rune(text)
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for i := 0; i < len(runes) && len(names) < maxMarkersPerText; i++ {
+		if !isAtMark(runes[i]) || !markerAllowed(runes, i) {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && isMentionRune(runes[end]) {
+			end++
+		}
+		if end == i+1 {
+			continue
+		}
+
+		candidate := runes[i+1 : end]
+		if len(candidate) > MaxMentionLength {
+			candidate = candidate[:MaxMentionLength]
+		}
+		i = end - 1
+		if name := string(candidate); !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// MentionPrefixes 返回候选用户名的全部前缀，从长到短排列，用于匹配已有用户名
+func MentionPrefixes(candidate string) []string {
+	runes := []rune(candidate)
+	prefixes := make([]string, 0, len(runes))
+	for n := len(runes); n > 0; n-- {
+		prefixes = append(prefixes, string(runes[:n]))
+	}
+	return prefixes
+}
@@ -0,0 +1,84 @@
+package utils
+
+import "unicode"
+
+// traditionalPairs 常用繁体字与简体字对照，每两个字符为一组（繁体在前）
+const traditionalPairs = "" +
+	"萬万與与醜丑專专業业叢丛東东絲丝丟丢兩两嚴严喪丧個个豐丰臨临為为麗丽舉举麼么義义烏乌樂乐喬乔習习鄉乡書书買买亂乱爭争於于虧亏雲云亞亚產产畝亩親亲褻亵億亿僅仅從从" +
+	"侖仑倉仓儀仪們们價价眾众優优夥伙會会傘伞偉伟傳传傷伤倫伦偽伪體体餘余傭佣僉佥俠侠侶侣僥侥偵侦側侧僑侨儈侩儂侬儔俦儼俨倆俩儷俪儉俭債债傾倾僂偻僨偾償偿儲储兒儿兌兑" +
+	"黨党蘭兰關关興兴茲兹養养獸兽內内岡冈冊册寫写軍军農农馮冯衝冲決决況况凍冻淨净淒凄涼凉減减湊凑凜凛幾几鳳凤憑凭凱凯擊击鑿凿芻刍劃划劉刘則则剛刚創创刪删別别劑剂剮剐" +
+	"劍剑剝剥劇剧勸劝辦办務务動动勵励勁劲勞劳勢势勳勋勻匀匱匮區区醫医華华協协單单賣卖盧卢鹵卤臥卧衛卫卻却廠厂廳厅曆历厲厉壓压厭厌廁厕廂厢廈厦廚厨廄厩縣县參参雙双發发" +
+	"變变敘叙疊叠葉叶號号嘆叹嘰叽嚇吓呂吕嗎吗噸吨聽听啟启吳吴嘔呕唄呗員员嗆呛嗚呜詠咏嚨咙嚀咛響响啞哑噠哒嘵哓嗶哔嘩哗噲哙喲哟嘮唠喚唤嘖啧嗇啬囉啰嘯啸噴喷嘍喽囁嗫噯嗳" +
+	"噓嘘嚶嘤囑嘱嚕噜團团園园囪囱圍围圇囵國国圖图圓圆聖圣場场壞坏塊块堅坚壇坛壩坝塢坞墳坟墜坠壟垄壘垒墾垦墊垫塹堑墮堕牆墙壯壮聲声殼壳壺壶處处備备復复夠够頭头誇夸夾夹" +
+	"奪夺奩奁奐奂奮奋獎奖奧奥妝妆婦妇媽妈嫵妩嫗妪婁娄婭娅嬈娆嬌娇孌娈娛娱媧娲嫻娴嬰婴嬋婵嬸婶媼媪嬡嫒嬪嫔嬤嬷孫孙學学孿孪寧宁寶宝實实寵宠審审憲宪宮宫寬宽賓宾寢寝對对" +
+	"尋寻導导壽寿將将爾尔塵尘堯尧尷尴屍尸盡尽層层屜屉屆届屬属屢屡嶼屿歲岁豈岂嶇岖崗岗嵐岚島岛嶺岭嶽岳峽峡崢峥巒峦嶗崂嶄崭嶸嵘巔巅鞏巩幣币帥帅師师幃帏帳帐簾帘幟帜帶带" +
+	"幀帧幫帮幗帼冪幂莊庄慶庆廬庐庫库應应廟庙龐庞廢废開开異异棄弃張张彌弥彎弯彈弹強强歸归當当錄录彥彦徹彻徑径禦御憶忆懺忏憂忧懷怀態态慫怂悵怅愴怆憐怜總总懟怼戀恋懇恳" +
+	"惡恶慟恸懨恹愷恺惻恻惱恼悅悦懸悬慳悭憫悯驚惊懼惧慘惨懲惩憊惫愜惬慚惭憚惮慣惯慍愠憤愤願愿懾慑懣懑懶懒戇戆戲戏戧戗戰战戶户紮扎撲扑執执擴扩掃扫揚扬擾扰撫抚拋抛摳抠" +
+	"掄抡搶抢護护報报擔担擬拟攏拢揀拣擁拥攔拦擰拧撥拨擇择掛挂摯挚攣挛撾挝撻挞挾挟撓挠擋挡掙挣擠挤揮挥撈捞損损撿捡換换搗捣據据擄掳摑掴擲掷撣掸摻掺摜掼攬揽攙搀擱搁摟搂" +
+	"攪搅攜携攝摄擺摆搖摇擯摈攤摊撐撑攆撵擷撷擼撸攛撺擻擞攢攒敵敌斂敛數数齋斋斕斓鬥斗斬斩斷断無无舊旧時时曠旷曇昙晝昼顯显晉晋曬晒曉晓曄晔暈晕暉晖暫暂曖暧術术樸朴機机" +
+	"殺杀雜杂權权條条來来楊杨傑杰極极構构樞枢棗枣櫪枥槍枪楓枫梟枭櫃柜檸柠柵栅標标棧栈櫛栉棟栋櫨栌櫟栎欄栏樹树棲栖樣样欒栾椏桠橈桡楨桢檔档橋桥樺桦檜桧槳桨樁桩夢梦檢检" +
+	"欞棂槨椁櫝椟欏椤橢椭樓楼欖榄櫬榇櫚榈櫸榉檻槛檳槟橫横檣樯櫻樱櫥橱櫓橹檁檩歡欢歐欧殲歼歿殁殤殇殘残殞殒殮殓殯殡毆殴毀毁轂毂畢毕斃毙氈毡氣气氫氢氬氩氳氲匯汇漢汉湯汤" +
+	"洶汹溝沟沒没漚沤瀝沥淪沦滄沧滬沪濘泞淚泪瀧泷瀘泸瀉泻潑泼澤泽涇泾潔洁灑洒窪洼淺浅漿浆澆浇濁浊測测濟济瀏浏渾浑滸浒濃浓潯浔濤涛澇涝漣涟渦涡渙涣滌涤潤润澗涧漲涨澀涩" +
+	"澱淀淵渊漬渍瀆渎漸渐漁渔滲渗溫温遊游灣湾濕湿潰溃濺溅滾滚滯滞灩滟滿满瀅滢濾滤濫滥灤滦濱滨灘滩瀠潆瀟潇瀲潋濰潍潛潜瀾澜瀨濑瀕濒灝灏滅灭燈灯靈灵災灾燦灿爐炉燉炖煒炜" +
+	"熗炝點点煉炼熾炽爍烁爛烂烴烃燭烛煙烟煩烦燒烧燁烨燴烩燙烫燼烬熱热煥焕燜焖愛爱爺爷牘牍犛牦牽牵犧牺犢犊狀状獷犷猶犹狽狈獰狞獨独狹狭獅狮獪狯猙狰獄狱猻狲獵猎獼猕豬猪" +
+	"貓猫獻献獺獭璣玑瑪玛瑋玮環环現现璽玺瓏珑琺珐璉琏瑣琐瓊琼瑤瑶璦瑷瓔璎甌瓯電电畫画暢畅疇畴療疗瘧疟瘍疡瘡疮瘋疯癰痈痙痉癢痒癆痨瘓痪癇痫癡痴瘺瘘癟瘪癱瘫癮瘾癩癞癬癣" +
+	"癲癫皚皑皺皱盞盏鹽盐監监蓋盖盜盗盤盘矚瞩瞼睑瞞瞒矯矫磯矶礬矾礦矿碼码磚砖硯砚礪砺礫砾礎础碩硕確确鹼碱礙碍磧碛禮礼禎祯禱祷禍祸祿禄稟禀種种積积稱称穢秽穩稳窮穷竊窃" +
+	"竅窍竄窜窩窝窺窥竇窦豎竖競竞篤笃筍笋筆笔箋笺籠笼築筑篩筛箏筝籌筹簽签簡简簍篓籃篮籬篱籟籁類类糞粪糧粮緊紧糾纠紀纪約约紅红紋纹納纳紐纽純纯紗纱紙纸級级紛纷紡纺細细" +
+	"紳绅紹绍終终組组絆绊結结絕绝絞绞絡络絢绚給给絨绒統统絹绢綁绑經经綜综綠绿綢绸線线綬绶維维綱纲網网綴缀綸纶綺绮綻绽綽绰綿绵緒绪緝缉緞缎締缔緣缘編编緩缓緬缅緯纬練练" +
+	"緻致縫缝縮缩縱纵縷缕績绩繃绷織织繞绕繡绣繩绳繪绘繫系繭茧繳缴繼继續续纏缠纖纤纜缆罰罚罵骂罷罢羅罗羈羁翹翘聳耸恥耻聶聂職职聯联聰聪肅肃腸肠膚肤腎肾腫肿脹胀脅胁膽胆" +
+	"勝胜朧胧脈脉膠胶臍脐腦脑膿脓腳脚脫脱臉脸臘腊膩腻騰腾輿舆艦舰艙舱艱艰艷艳藝艺節节蕪芜蘆芦葦苇蒼苍蘋苹莖茎薦荐莢荚蕎荞薈荟蕩荡榮荣葷荤熒荧蔭荫藥药萊莱蓮莲獲获瑩莹" +
+	"鶯莺蘿萝螢萤營营縈萦蕭萧薩萨蔥葱蔣蒋藍蓝薊蓟薔蔷藹蔼蘊蕴蘚藓虜虏慮虑蟲虫雖虽蝦虾蝕蚀蟻蚁螞蚂蠶蚕蠔蚝蠱蛊蠣蛎蠻蛮蟄蛰蛻蜕蝸蜗蠟蜡蠅蝇蟬蝉蠍蝎銜衔補补襯衬襖袄襪袜" +
+	"襲袭裝装襠裆褲裤襤褴見见觀观規规覓觅視视覽览覺觉覬觊觸触譽誉計计訂订認认譏讥討讨讓让訓训議议訊讯記记講讲諱讳訝讶許许論论訟讼諷讽設设訪访訣诀證证評评詛诅識识詐诈" +
+	"訴诉診诊詞词譯译試试詩诗誠诚話话誕诞詭诡詢询該该詳详說说誡诫誣诬語语誤误誘诱誦诵請请諸诸諾诺讀读誹诽課课誰谁調调諒谅談谈誼谊謀谋諜谍謊谎諧谐謂谓諭谕讒谗諮谘諺谚" +
+	"諦谛謎谜謝谢謠谣謗谤謙谦謹谨謬谬譜谱譴谴貝贝貞贞負负貢贡財财責责賢贤敗败賬账貨货質质販贩貪贪貧贫貶贬購购貯贮貫贯賤贱貼贴貴贵貸贷貿贸費费賀贺賊贼賈贾賄贿賃赁賂赂" +
+	"贓赃資资賑赈賒赊賦赋賭赌贖赎賞赏賜赐賠赔賴赖贅赘賺赚賽赛贊赞贈赠贍赡贏赢趙赵趕赶趨趋躍跃踐践蹤踪軀躯車车軌轨軒轩轉转輪轮軟软轟轰軸轴輕轻載载轎轿較较輔辅輛辆輩辈" +
+	"輝辉輯辑輸输轄辖轍辙辭辞辯辩辮辫邊边遼辽達达遷迁過过邁迈運运還还這这進进遠远違违連连遲迟適适選选遜逊遞递邏逻遺遗遙遥鄧邓郵邮鄒邹鄰邻鬱郁鄭郑醬酱釀酿釋释裏里鑒鉴" +
+	"針针釘钉釣钓鈣钙鈍钝鈔钞鍾钟鋼钢鑰钥欽钦鈞钧鉤钩鈕钮錢钱鉗钳鑽钻鐵铁鉛铅鑄铸銅铜鋁铝銀银鋤锄錳锰鎖锁鏈链錘锤錯错鍋锅鍵键鋸锯鐘钟鏡镜鑼锣鎮镇鑲镶長长門门閃闪閉闭" +
+	"問问闖闯閑闲間间悶闷閘闸鬧闹閨闺聞闻閥阀閣阁閱阅闊阔闡阐闢辟隊队陽阳陰阴陣阵階阶際际陸陆隴陇陳陈陝陕隕陨險险隨随隱隐隸隶難难雛雏靂雳霧雾霽霁黴霉靄霭靚靓靜静韁缰" +
+	"韋韦韌韧韓韩韜韬韻韵頁页頂顶頃顷項项順顺須须頑顽顧顾頓顿頒颁頌颂預预顱颅領领頗颇頸颈頰颊頻频頹颓穎颖顆颗題题顏颜額额顛颠顫颤顰颦顴颧風风颯飒颱台颳刮飄飘飆飙飛飞" +
+	"饑饥飢饥飪饪飯饭飲饮飾饰飽饱飼饲餌饵饒饶餃饺餅饼餓饿餡馅館馆饋馈饞馋饅馒馬马馭驭馱驮馳驰馴驯驅驱駁驳驢驴駛驶駒驹駐驻駝驼駕驾驛驿駭骇駱骆驗验駿骏騁骋騎骑騙骗騷骚" +
+	"驕骄驟骤骯肮髏髅髒脏鬆松鬍胡鬚须鬢鬓魚鱼魯鲁鮑鲍鯉鲤鯨鲸鰻鳗鱗鳞鳥鸟鳩鸠雞鸡鴉鸦鴨鸭鴿鸽鵝鹅鶴鹤鷹鹰鷺鹭鸚鹦鹹咸麥麦黃黄黷黩齊齐齒齿齡龄龍龙龔龚龕龛龜龟罈坛閤合" +
+	"啓启衆众週周麵面髮发範范後后製制鍊炼彙汇"
+
+// traditionalToSimplified 繁体到简体的映射
+var traditionalToSimplified = func() map[rune]rune {
+	runes := []rune(traditionalPairs)
+	m := make(map[rune]rune, len(runes)/2)
+	for i := 0; i+1 < len(runes); i += 2 {
+		m[runes[i]] = runes[i+1]
+	}
+	return m
+}()
+
+// NormalizeRune 规范化单个字符：全角转半角、大写转小写、繁体转简体
+func NormalizeRune(r rune) rune {
+	switch {
+	case r == 0x3000:
+		r = ' '
+	case r >= 0xFF01 && r <= 0xFF5E:
+		r -= 0xFEE0
+	}
+	r = unicode.ToLower(r)
+	if s, ok := traditionalToSimplified[r]; ok {
+		return s
+	}
+	return r
+}
+
+// NormalizeText 将文本规范化用于敏感词匹配
+// 除逐字规范化外，还会去掉空白、标点、符号等分隔字符，避免“傻 逼”“s.b”之类的规避写法
+// 返回规范化后的字符以及每个字符在原文（按 rune 计）中的下标
+func NormalizeText(text string) ([]rune, []int) {
+	runes := []rune(text)
+	normalized := make([]rune, 0, len(runes))
+	positions := make([]int, 0, len(runes))
+	for i, r := range runes {
+		r = NormalizeRune(r)
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		normalized = append(normalized, r)
+		positions = append(positions, i)
+	}
+	return normalized, positions
+}